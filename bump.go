@@ -0,0 +1,78 @@
+package semver
+
+import "math"
+
+// BumpKind is an enum-like type used with Version.Bump, indicating which version component should be
+// incremented. The values are ordered so that a larger BumpKind always represents a larger change.
+type BumpKind int
+
+const (
+	// BumpNone leaves the version unchanged.
+	BumpNone BumpKind = iota
+
+	// BumpPatch increments the patch version component.
+	BumpPatch
+
+	// BumpMinor increments the minor version component and resets the patch component to zero.
+	BumpMinor
+
+	// BumpMajor increments the major version component and resets the minor and patch components to zero.
+	BumpMajor
+)
+
+// String returns a lowercase name for the BumpKind, such as "minor".
+func (k BumpKind) String() string {
+	switch k {
+	case BumpNone:
+		return "none"
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	default:
+		return "unknown"
+	}
+}
+
+// Bump returns the next release version after v for the specified kind of change. The prerelease and
+// build components are always removed from the result.
+//
+// If v is a prerelease of a version that already has the requested kind of change, the result is simply
+// that version: for instance, a minor bump of "1.3.0-rc.1" is "1.3.0", but a minor bump of "1.3.1-rc.1"
+// is "1.4.0". This matches the behavior of npm's "semver inc".
+//
+// BumpNone, or any unknown BumpKind, returns v unchanged. So does a bump that would increment a component
+// that is already math.MaxInt, since there is no higher version of that kind.
+func (v Version) Bump(kind BumpKind) Version {
+	switch kind {
+	case BumpMajor:
+		if v.prerelease == "" || v.minor != 0 || v.patch != 0 {
+			if v.major == math.MaxInt {
+				return v
+			}
+			v.major++
+		}
+		v.minor, v.patch = 0, 0
+	case BumpMinor:
+		if v.prerelease == "" || v.patch != 0 {
+			if v.minor == math.MaxInt {
+				return v
+			}
+			v.minor++
+		}
+		v.patch = 0
+	case BumpPatch:
+		if v.prerelease == "" {
+			if v.patch == math.MaxInt {
+				return v
+			}
+			v.patch++
+		}
+	default:
+		return v
+	}
+	v.prerelease, v.build = "", ""
+	return v
+}
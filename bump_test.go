@@ -0,0 +1,60 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBump(t *testing.T) {
+	for _, test := range []struct {
+		from     string
+		kind     BumpKind
+		expected string
+	}{
+		{"1.2.3", BumpNone, "1.2.3"},
+		{"1.2.3-rc.1+b", BumpNone, "1.2.3-rc.1+b"},
+
+		{"1.2.3", BumpPatch, "1.2.4"},
+		{"1.2.3+b", BumpPatch, "1.2.4"},
+		{"1.2.3-rc.1", BumpPatch, "1.2.3"},
+
+		{"1.2.3", BumpMinor, "1.3.0"},
+		{"1.3.0-rc.1", BumpMinor, "1.3.0"},
+		{"1.3.1-rc.1", BumpMinor, "1.4.0"},
+
+		{"1.2.3", BumpMajor, "2.0.0"},
+		{"2.0.0-rc.1", BumpMajor, "2.0.0"},
+		{"2.1.0-rc.1", BumpMajor, "3.0.0"},
+		{"2.0.1-rc.1", BumpMajor, "3.0.0"},
+		{"0.0.0", BumpMajor, "1.0.0"},
+
+		{"1.2.9223372036854775807", BumpPatch, "1.2.9223372036854775807"},
+		{"1.2.9223372036854775807-rc.1", BumpPatch, "1.2.9223372036854775807"},
+		{"1.9223372036854775807.3+b", BumpMinor, "1.9223372036854775807.3+b"},
+		{"1.9223372036854775807.3", BumpPatch, "1.9223372036854775807.4"},
+		{"9223372036854775807.2.3-rc.1", BumpMajor, "9223372036854775807.2.3-rc.1"},
+		{"9223372036854775807.0.0-rc.1", BumpMajor, "9223372036854775807.0.0"},
+		{"9223372036854775807.2.3", BumpMinor, "9223372036854775807.3.0"},
+	} {
+		t.Run(test.from+" "+test.kind.String(), func(t *testing.T) {
+			v, err := Parse(test.from)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v.Bump(test.kind).String())
+		})
+	}
+
+	t.Run("unknown kind", func(t *testing.T) {
+		v, _ := Parse("1.2.3")
+		assert.Equal(t, v, v.Bump(BumpKind(99)))
+	})
+}
+
+func TestBumpKindString(t *testing.T) {
+	assert.Equal(t, "none", BumpNone.String())
+	assert.Equal(t, "patch", BumpPatch.String())
+	assert.Equal(t, "minor", BumpMinor.String())
+	assert.Equal(t, "major", BumpMajor.String())
+	assert.Equal(t, "unknown", BumpKind(99).String())
+}
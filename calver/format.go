@@ -0,0 +1,121 @@
+// Package calver implements calendar versioning (https://calver.org), in which some components of a
+// version are derived from the release date, such as "2026.10.18", "26.04.1" or "2026.10-beta".
+//
+// Since there is no single CalVer scheme, versions are always parsed according to a Format, such as
+// "YYYY.0M.0D" or "YY.0M.MICRO", which says which components a version has and how they are written.
+package calver
+
+import (
+	"errors"
+	"strings"
+)
+
+// Format is a CalVer format specification, created by ParseFormat. The zero value is not a valid format.
+type Format struct {
+	spec   string
+	tokens []token
+}
+
+type field int
+
+const (
+	fieldYear field = iota
+	fieldMonth
+	fieldDay
+	fieldMicro
+	fieldModifier
+)
+
+type token struct {
+	name      string
+	field     field
+	separator string // the text that precedes this token in a version, or "" for the first token
+}
+
+// tokenFields lists the supported tokens, longest first so that "MODIFIER" is not mistaken for "MM".
+var tokenFields = []struct {
+	name  string
+	field field
+}{
+	{"MODIFIER", fieldModifier},
+	{"MICRO", fieldMicro},
+	{"YYYY", fieldYear},
+	{"YY", fieldYear},
+	{"0Y", fieldYear},
+	{"MM", fieldMonth},
+	{"0M", fieldMonth},
+	{"DD", fieldDay},
+	{"0D", fieldDay},
+}
+
+var errInvalidFormat = errors.New("invalid CalVer format")
+
+// ParseFormat attempts to parse a format specification, which consists of tokens separated by ".", "-"
+// or "_". The supported tokens are:
+//
+//   - YYYY: the full year, as in "2006" or "2026".
+//   - YY: the year minus 2000, without padding, as in "6" or "26".
+//   - 0Y: the year minus 2000, zero-padded to two digits, as in "06" or "26".
+//   - MM: the month, without padding, as in "1" or "10".
+//   - 0M: the month, zero-padded to two digits, as in "01" or "10".
+//   - DD: the day of the month, without padding, as in "1" or "18".
+//   - 0D: the day of the month, zero-padded to two digits, as in "01" or "18".
+//   - MICRO: a number that increases for each release in the same period, starting at zero.
+//   - MODIFIER: an optional tag such as "beta" or "rc.1", which must be the last token and has the same
+//     syntax as a semver prerelease. A version with a modifier has lower precedence than the same version
+//     without one.
+//
+// Each kind of component may appear only once: for instance, "YYYY.YY" is not a valid format. There must
+// be at least one numeric component.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and Format{} as the first.
+func ParseFormat(spec string) (Format, error) {
+	f := Format{spec: spec}
+	seen := make(map[field]bool)
+	rest := spec
+	separator := ""
+	for {
+		name, fld, ok := matchToken(rest)
+		if !ok || seen[fld] || (fld == fieldModifier && len(f.tokens) == 0) ||
+			(len(f.tokens) > 0 && f.tokens[len(f.tokens)-1].field == fieldModifier) {
+			return Format{}, errInvalidFormat
+		}
+		seen[fld] = true
+		f.tokens = append(f.tokens, token{name: name, field: fld, separator: separator})
+		rest = rest[len(name):]
+		if rest == "" {
+			return f, nil
+		}
+		if !isSeparator(rest[0]) {
+			return Format{}, errInvalidFormat
+		}
+		separator, rest = rest[:1], rest[1:]
+	}
+}
+
+// String returns the format specification that the Format was parsed from.
+func (f Format) String() string {
+	return f.spec
+}
+
+func (f Format) has(fld field) bool {
+	for _, t := range f.tokens {
+		if t.field == fld {
+			return true
+		}
+	}
+	return false
+}
+
+func matchToken(s string) (string, field, bool) {
+	for _, t := range tokenFields {
+		if strings.HasPrefix(s, t.name) {
+			return t.name, t.field, true
+		}
+	}
+	return "", 0, false
+}
+
+func isSeparator(ch byte) bool {
+	return ch == '.' || ch == '-' || ch == '_'
+}
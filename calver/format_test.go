@@ -0,0 +1,39 @@
+package calver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, spec := range []string{
+		"YYYY.0M.0D", "YY.0M.MICRO", "YYYY.0M-MODIFIER", "0Y.MM.DD_MICRO", "YYYY", "MICRO", "YYYY.MM.MICRO.MODIFIER",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			f, err := ParseFormat(spec)
+			require.NoError(t, err)
+			assert.Equal(t, spec, f.String())
+		})
+	}
+}
+
+func TestParseFormatInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"", "YYYY.", ".YYYY", "YYYY..0M", "YYYY 0M", "YYYY.YY", "0M.MM", "YYYY.MODIFIER.MICRO", "MODIFIER",
+		"YYYYY", "yyyy.0m", "YYYY.0M.WW", "YYYY0M",
+	} {
+		t.Run(spec, func(t *testing.T) {
+			f, err := ParseFormat(spec)
+			assert.Error(t, err)
+			assert.Equal(t, Format{}, f)
+		})
+	}
+}
+
+func mustParseFormat(t *testing.T, spec string) Format {
+	f, err := ParseFormat(spec)
+	require.NoError(t, err)
+	return f
+}
@@ -0,0 +1,90 @@
+package calver
+
+import (
+	"errors"
+	"time"
+)
+
+// Clock provides the current time to Today and Next. Tests and build tools can supply their own
+// implementation, or use ClockFunc, instead of SystemClock.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc is an adapter that allows an ordinary function to be used as a Clock.
+type ClockFunc func() time.Time
+
+// Now calls f().
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is a Clock that returns the current system time in UTC.
+var SystemClock Clock = ClockFunc(func() time.Time { return time.Now().UTC() })
+
+var (
+	errClockBehind = errors.New("the latest CalVer version is later than the current date")
+	errNoMicro     = errors.New("a version for the current date already exists, and the format has no MICRO")
+	errMicroTooBig = errors.New("the MICRO component of the latest CalVer version cannot be incremented")
+	errWrongFormat = errors.New("the latest CalVer version does not use the same format")
+)
+
+// Today returns the first version for the current date, according to the clock and the format: its
+// date components are taken from the clock's current time, in whatever time zone the clock uses, its
+// MICRO component (if any) is zero, and it has no modifier.
+func (f Format) Today(clock Clock) Version {
+	now := clock.Now()
+	v := Version{format: f}
+	for _, t := range f.tokens {
+		switch t.field {
+		case fieldYear:
+			v.year = now.Year()
+		case fieldMonth:
+			v.month = int(now.Month())
+		case fieldDay:
+			v.day = now.Day()
+		}
+	}
+	return v
+}
+
+// Next returns the version that should be released after latest, according to the clock and the format.
+//
+// If the date components of latest are earlier than the current date, this is the same as Today. If
+// they are the same, and latest has a modifier, the result is latest without the modifier; so the
+// release after "2026.10.0-rc.1" is "2026.10.0". Otherwise, the MICRO component is incremented, or an
+// error is returned if the format has no MICRO or if MICRO is already 999999999, the highest value that can
+// be parsed. It is also an error if latest is later than the current date, or if it was not parsed with
+// the same format. If latest is Version{}, meaning that there have been no releases yet, this is the same
+// as Today.
+func (f Format) Next(clock Clock, latest Version) (Version, error) {
+	next := f.Today(clock)
+	if len(latest.format.tokens) == 0 {
+		return next, nil
+	}
+	if latest.format.spec != f.spec {
+		return Version{}, errWrongFormat
+	}
+	for _, t := range f.tokens {
+		if t.field == fieldMicro || t.field == fieldModifier {
+			continue
+		}
+		if a, b := latest.get(t.field), next.get(t.field); a != b {
+			if a > b {
+				return Version{}, errClockBehind
+			}
+			return next, nil
+		}
+	}
+	switch {
+	case latest.Modifier() != "":
+		next.micro = latest.micro
+	case !f.has(fieldMicro):
+		return Version{}, errNoMicro
+	case latest.micro >= maxMicro:
+		return Version{}, errMicroTooBig
+	default:
+		next.micro = latest.micro + 1
+	}
+	return next, nil
+}
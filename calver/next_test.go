@@ -0,0 +1,93 @@
+package calver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixedClock(year int, month time.Month, day int) Clock {
+	return ClockFunc(func() time.Time { return time.Date(year, month, day, 12, 0, 0, 0, time.UTC) })
+}
+
+func TestToday(t *testing.T) {
+	clock := fixedClock(2026, time.April, 5)
+	for format, expected := range map[string]string{
+		"YYYY.0M.0D":       "2026.04.05",
+		"YY.MM.DD":         "26.4.5",
+		"0Y.0M.MICRO":      "26.04.0",
+		"YYYY.0M-MODIFIER": "2026.04",
+	} {
+		assert.Equal(t, expected, mustParseFormat(t, format).Today(clock).String(), format)
+	}
+}
+
+func TestNext(t *testing.T) {
+	clock := fixedClock(2026, time.October, 18)
+	for _, test := range []struct {
+		format, latest, expected string
+	}{
+		{"YYYY.0M.MICRO", "", "2026.10.0"},
+		{"YYYY.0M.MICRO", "2026.09.4", "2026.10.0"},
+		{"YYYY.0M.MICRO", "2025.12.9", "2026.10.0"},
+		{"YYYY.0M.MICRO", "2026.10.0", "2026.10.1"},
+		{"YYYY.0M.MICRO", "2026.10.9", "2026.10.10"},
+		{"YYYY.0M.MICRO-MODIFIER", "2026.10.2-rc.1", "2026.10.2"},
+		{"YYYY.0M.MICRO-MODIFIER", "2026.10.2", "2026.10.3"},
+		{"YYYY.0M.MICRO-MODIFIER", "2026.09.2-rc.1", "2026.10.0"},
+		{"YYYY.0M.0D", "2026.10.17", "2026.10.18"},
+		{"YYYY.0M-MODIFIER", "2026.10-beta", "2026.10"},
+		{"YY.0M.MICRO", "26.10.3", "26.10.4"},
+		{"YYYY.0M.MICRO", "2026.10.999999998", "2026.10.999999999"},
+	} {
+		t.Run(test.format+" "+test.latest, func(t *testing.T) {
+			f := mustParseFormat(t, test.format)
+			var latest Version
+			if test.latest != "" {
+				var err error
+				latest, err = f.Parse(test.latest)
+				require.NoError(t, err)
+			}
+			next, err := f.Next(clock, latest)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, next.String())
+		})
+	}
+}
+
+func TestNextErrors(t *testing.T) {
+	clock := fixedClock(2026, time.October, 18)
+	for _, test := range []struct {
+		format, latestFormat, latest string
+	}{
+		{"YYYY.0M.0D", "", "2026.10.18"},
+		{"YYYY.0M", "", "2026.10"},
+		{"YYYY.0M.MICRO", "", "2026.11.0"},
+		{"YYYY.0M.MICRO", "", "2027.01.0"},
+		{"YYYY.0M.MICRO", "", "2026.10.999999999"},
+		{"YYYY.MM.MICRO", "YY.0M", "26.10"},
+		{"YYYY.MM.MICRO", "YYYY.0M.MICRO", "2026.10.1"},
+	} {
+		t.Run(test.format+" "+test.latest, func(t *testing.T) {
+			f := mustParseFormat(t, test.format)
+			latestFormat := f
+			if test.latestFormat != "" {
+				latestFormat = mustParseFormat(t, test.latestFormat)
+			}
+			latest, err := latestFormat.Parse(test.latest)
+			require.NoError(t, err)
+			next, err := f.Next(clock, latest)
+			assert.Error(t, err)
+			assert.Equal(t, Version{}, next)
+		})
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+	assert.False(t, now.Before(before.Add(-time.Second)))
+	assert.Equal(t, time.UTC, now.Location())
+}
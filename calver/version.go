@@ -0,0 +1,264 @@
+package calver
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// Version is a calendar version that was parsed or generated according to a Format.
+type Version struct {
+	format   Format
+	year     int
+	month    int
+	day      int
+	micro    int
+	modifier semver.Version // "0.0.0-" followed by the modifier, if any, so that modifiers can be compared
+}
+
+var (
+	errInvalidVersion    = errors.New("invalid CalVer version")
+	errTooManyComponents = errors.New("CalVer version has more than three numeric components")
+)
+
+// Parse attempts to parse a string into a Version according to the format.
+//
+// Numeric components must be written exactly as the format specifies: for instance, "0M" requires two
+// digits and "MM" does not allow a leading zero. The month and day, if present, must be valid for the
+// calendar. If the format ends with a modifier, the modifier and its separator may be omitted.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and Version{} as the first.
+func (f Format) Parse(s string) (Version, error) {
+	if len(f.tokens) == 0 {
+		return Version{}, errInvalidFormat
+	}
+	v := Version{format: f}
+	rest := s
+	for i, t := range f.tokens {
+		if t.field == fieldModifier && rest == "" && i > 0 {
+			break
+		}
+		if len(rest) < len(t.separator) || rest[:len(t.separator)] != t.separator {
+			return Version{}, errInvalidVersion
+		}
+		rest = rest[len(t.separator):]
+		if t.field == fieldModifier {
+			modifier, err := semver.Parse("0.0.0-" + rest)
+			if err != nil || modifier.GetBuild() != "" {
+				return Version{}, errInvalidVersion
+			}
+			v.modifier, rest = modifier, ""
+			break
+		}
+		end := 0
+		for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+			end++
+		}
+		n, ok := parseComponent(t.name, rest[:end])
+		if !ok {
+			return Version{}, errInvalidVersion
+		}
+		v.set(t.field, n)
+		rest = rest[end:]
+	}
+	if rest != "" || !v.validDate() {
+		return Version{}, errInvalidVersion
+	}
+	return v, nil
+}
+
+// Format returns the format of the version.
+func (v Version) Format() Format {
+	return v.format
+}
+
+// Year returns the full year of the version, such as 2026 for either "2026.10" or "26.10", or zero if
+// the format has no year.
+func (v Version) Year() int {
+	return v.year
+}
+
+// Month returns the month of the version, from 1 to 12, or zero if the format has no month.
+func (v Version) Month() int {
+	return v.month
+}
+
+// Day returns the day of the month of the version, from 1 to 31, or zero if the format has no day.
+func (v Version) Day() int {
+	return v.day
+}
+
+// Micro returns the MICRO component of the version, or zero if the format has none.
+func (v Version) Micro() int {
+	return v.micro
+}
+
+// Modifier returns the modifier of the version, such as "beta" in "2026.10-beta", or an empty string if
+// it has none.
+func (v Version) Modifier() string {
+	return v.modifier.GetPrerelease()
+}
+
+// String returns the version written according to its format.
+func (v Version) String() string {
+	var buf []byte
+	for _, t := range v.format.tokens {
+		if t.field == fieldModifier {
+			if v.modifier.GetPrerelease() != "" {
+				buf = append(buf, t.separator...)
+				buf = append(buf, v.modifier.GetPrerelease()...)
+			}
+			continue
+		}
+		buf = append(buf, t.separator...)
+		n := v.written(t)
+		if (t.name == "0Y" || t.name == "0M" || t.name == "0D") && n < 10 {
+			buf = append(buf, '0')
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+	}
+	return string(buf)
+}
+
+// ComparePrecedence compares this Version to another Version. It returns -1 if v has lower precedence
+// than other, 1 if v has higher precedence, or 0 if the same.
+//
+// The numeric components are compared in the order in which they appear in the format of v, and a
+// version with a modifier has lower precedence than the same version without one; modifiers are compared
+// like semver prereleases. For formats that are shaped like semantic versions, such as "YYYY.0M.MICRO"
+// or "YY.MM.MICRO-MODIFIER", this gives the same result as Version.ComparePrecedence in the semver
+// package. Years are compared as full years, so "26.04.1" in the format "YY.0M.MICRO" and "2026.04.1" in
+// the format "YYYY.0M.MICRO" are equal. Versions of formats with different components cannot be
+// meaningfully compared.
+func (v Version) ComparePrecedence(other Version) int {
+	for _, t := range v.format.tokens {
+		if t.field == fieldModifier {
+			continue
+		}
+		if a, b := v.get(t.field), other.get(t.field); a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	a, b := v.modifier.GetPrerelease(), other.modifier.GetPrerelease()
+	switch {
+	case a == "" && b == "":
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	default:
+		return v.modifier.ComparePrecedence(other.modifier)
+	}
+}
+
+// Semver converts the version to a semantic version, by using its numeric components in order, as they
+// are written, as the major, minor and patch versions, and its modifier as the prerelease. For instance,
+// "2026.10.18" becomes "2026.10.18", "26.04.1" becomes "26.4.1", and "2026.10-beta" becomes
+// "2026.10.0-beta".
+//
+// It returns an error if the format has more than three numeric components.
+func (v Version) Semver() (semver.Version, error) {
+	var components []int
+	for _, t := range v.format.tokens {
+		if t.field != fieldModifier {
+			components = append(components, v.written(t))
+		}
+	}
+	if len(components) > 3 {
+		return semver.Version{}, errTooManyComponents
+	}
+	for len(components) < 3 {
+		components = append(components, 0)
+	}
+	s := fmt.Sprintf("%d.%d.%d", components[0], components[1], components[2])
+	if modifier := v.modifier.GetPrerelease(); modifier != "" {
+		s += "-" + modifier
+	}
+	return semver.Parse(s)
+}
+
+// written returns the number that represents the token's component in the string form of the version.
+func (v Version) written(t token) int {
+	if t.name == "YY" || t.name == "0Y" {
+		return v.year - 2000
+	}
+	return v.get(t.field)
+}
+
+func (v Version) get(fld field) int {
+	switch fld {
+	case fieldYear:
+		return v.year
+	case fieldMonth:
+		return v.month
+	case fieldDay:
+		return v.day
+	default:
+		return v.micro
+	}
+}
+
+func (v *Version) set(fld field, n int) {
+	switch fld {
+	case fieldYear:
+		v.year = n
+	case fieldMonth:
+		v.month = n
+	case fieldDay:
+		v.day = n
+	default:
+		v.micro = n
+	}
+}
+
+func (v Version) validDate() bool {
+	if v.format.has(fieldMonth) && (v.month < 1 || v.month > 12) {
+		return false
+	}
+	if !v.format.has(fieldDay) {
+		return true
+	}
+	maxDay := 31
+	if v.month != 0 {
+		year := v.year
+		if !v.format.has(fieldYear) {
+			year = 2000 // a leap year, so that February 29th is allowed
+		}
+		maxDay = time.Date(year, time.Month(v.month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	}
+	return v.day >= 1 && v.day <= maxDay
+}
+
+// maxComponentDigits is the maximum number of digits in a numeric component, so that every component fits
+// in an int on any platform.
+const maxComponentDigits = 9
+
+// maxMicro is the highest MICRO component that can be parsed.
+const maxMicro = 999999999
+
+// parseComponent parses the digits of a numeric component, enforcing the padding rules of its token.
+func parseComponent(tokenName, digits string) (int, bool) {
+	if digits == "" || len(digits) > maxComponentDigits {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(digits)
+	switch tokenName {
+	case "YYYY":
+		return n, len(digits) == 4 && digits[0] != '0'
+	case "YY":
+		return n + 2000, digits == strconv.Itoa(n)
+	case "0Y":
+		return n + 2000, len(digits) >= 2 && (len(digits) == 2 || digits[0] != '0')
+	case "0M", "0D":
+		return n, len(digits) == 2
+	default: // "MM", "DD", "MICRO"
+		return n, digits == strconv.Itoa(n)
+	}
+}
@@ -0,0 +1,144 @@
+package calver
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ semver.Comparable[Version] = Version{}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		format, input           string
+		year, month, day, micro int
+		modifier                string
+	}{
+		{"YYYY.0M.0D", "2026.10.18", 2026, 10, 18, 0, ""},
+		{"YYYY.0M.0D", "2024.02.29", 2024, 2, 29, 0, ""},
+		{"YY.0M.MICRO", "26.04.1", 2026, 4, 0, 1, ""},
+		{"YY.MM.MICRO", "6.4.0", 2006, 4, 0, 0, ""},
+		{"0Y.MM.MICRO", "06.4.12", 2006, 4, 0, 12, ""},
+		{"0Y.MM", "106.4", 2106, 4, 0, 0, ""},
+		{"YYYY.0M-MODIFIER", "2026.10-beta", 2026, 10, 0, 0, "beta"},
+		{"YYYY.0M-MODIFIER", "2026.10-rc.1", 2026, 10, 0, 0, "rc.1"},
+		{"YYYY.0M-MODIFIER", "2026.10", 2026, 10, 0, 0, ""},
+		{"YYYY.MM.MICRO.MODIFIER", "2026.1.3.dev-1", 2026, 1, 0, 3, "dev-1"},
+		{"MM.DD", "2.29", 0, 2, 29, 0, ""},
+		{"YYYY_MICRO", "2026_7", 2026, 0, 0, 7, ""},
+	} {
+		t.Run(test.format+" "+test.input, func(t *testing.T) {
+			v, err := mustParseFormat(t, test.format).Parse(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.year, v.Year())
+			assert.Equal(t, test.month, v.Month())
+			assert.Equal(t, test.day, v.Day())
+			assert.Equal(t, test.micro, v.Micro())
+			assert.Equal(t, test.modifier, v.Modifier())
+			assert.Equal(t, test.format, v.Format().String())
+			assert.Equal(t, test.input, v.String())
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, test := range []struct {
+		format, input string
+	}{
+		{"YYYY.0M.0D", ""},
+		{"YYYY.0M.0D", "2026.10"},
+		{"YYYY.0M.0D", "2026.10.18.1"},
+		{"YYYY.0M.0D", "2026.10.8"},
+		{"YYYY.0M.0D", "2026-10-18"},
+		{"YYYY.0M.0D", "2026.13.01"},
+		{"YYYY.0M.0D", "2026.00.01"},
+		{"YYYY.0M.0D", "2026.10.00"},
+		{"YYYY.0M.0D", "2025.02.29"},
+		{"YYYY.0M.0D", "2026.04.31"},
+		{"YYYY.0M.0D", "26.10.18"},
+		{"YYYY.0M.0D", "02026.10.18"},
+		{"YY.0M.MICRO", "026.04.1"},
+		{"YY.0M.MICRO", "26.04.01"},
+		{"YY.0M.MICRO", "26.4.1"},
+		{"YY.MM.MICRO", "26.04.1"},
+		{"0Y.MM", "6.4"},
+		{"0Y.MM", "006.4"},
+		{"MM.DD", "2.30"},
+		{"YYYY.0M-MODIFIER", "2026.10-"},
+		{"YYYY.0M-MODIFIER", "2026.10.beta"},
+		{"YYYY.0M-MODIFIER", "2026.10-beta+build"},
+		{"YYYY.0M-MODIFIER", "2026.10-be_ta"},
+		{"YYYY.0M-MODIFIER", "2026.10beta"},
+		{"YYYY.MICRO", "2026.9999999999"},
+		{"YYYY.MICRO", "2026.-1"},
+	} {
+		t.Run(test.format+" "+test.input, func(t *testing.T) {
+			v, err := mustParseFormat(t, test.format).Parse(test.input)
+			assert.Error(t, err)
+			assert.Equal(t, Version{}, v)
+		})
+	}
+
+	_, err := Format{}.Parse("2026")
+	assert.Error(t, err)
+}
+
+func TestComparePrecedence(t *testing.T) {
+	f := mustParseFormat(t, "YY.0M.MICRO-MODIFIER")
+	ordered := []string{"25.12.3", "26.01.0-alpha", "26.01.0-beta", "26.01.0-beta.2", "26.01.0", "26.01.1",
+		"26.01.10", "26.02.0"}
+	for i := range ordered {
+		for j := range ordered {
+			a, err := f.Parse(ordered[i])
+			require.NoError(t, err)
+			b, err := f.Parse(ordered[j])
+			require.NoError(t, err)
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, a.ComparePrecedence(b), "%s vs %s", ordered[i], ordered[j])
+
+			sa, err := a.Semver()
+			require.NoError(t, err)
+			sb, err := b.Semver()
+			require.NoError(t, err)
+			assert.Equal(t, expected, sa.ComparePrecedence(sb), "%s vs %s as semver", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestCompareAcrossYearStyles(t *testing.T) {
+	a, err := mustParseFormat(t, "YY.0M.MICRO").Parse("26.04.1")
+	require.NoError(t, err)
+	b, err := mustParseFormat(t, "YYYY.0M.MICRO").Parse("2026.04.1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, a.ComparePrecedence(b))
+}
+
+func TestSemver(t *testing.T) {
+	for _, test := range []struct {
+		format, input, expected string
+	}{
+		{"YYYY.0M.0D", "2026.10.18", "2026.10.18"},
+		{"YY.0M.MICRO", "26.04.1", "26.4.1"},
+		{"YYYY.0M-MODIFIER", "2026.10-beta", "2026.10.0-beta"},
+		{"YYYY", "2026", "2026.0.0"},
+	} {
+		v, err := mustParseFormat(t, test.format).Parse(test.input)
+		require.NoError(t, err)
+		sv, err := v.Semver()
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, sv.String())
+	}
+
+	v, err := mustParseFormat(t, "YYYY.0M.0D.MICRO").Parse("2026.10.18.1")
+	require.NoError(t, err)
+	_, err = v.Semver()
+	assert.Error(t, err)
+}
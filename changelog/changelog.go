@@ -0,0 +1,160 @@
+// Package changelog parses CHANGELOG.md files in the Keep a Changelog (https://keepachangelog.com) and
+// release-please styles into release entries keyed by semantic version.
+//
+// A release entry begins with any Markdown heading whose text starts with a version, optionally in
+// square brackets and optionally with a "v" prefix, as in all of these:
+//
+//	## [1.0.4](https://github.com/org/repo/compare/v1.0.3...v1.0.4) (2026-04-09)
+//	## [1.0.2] - 2021-01-20
+//	### v0.9.1
+//
+// Other headings, such as "### Bug Fixes", are part of the body of the entry they appear in.
+package changelog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// Entry is the section of a changelog for one released version.
+type Entry struct {
+	// Version is the version from the heading.
+	Version semver.Version
+
+	// Heading is the full heading line, including the leading "#" characters.
+	Heading string
+
+	// Link is the URL from the heading, such as "[1.0.4](url)", or from a Markdown link reference
+	// definition, such as "[1.0.4]: url", elsewhere in the file. It is "" if there is none.
+	Link string
+
+	// Date is the text following the version in the heading, with surrounding parentheses, hyphens
+	// and whitespace removed, such as "2026-04-09". It is not validated as a date.
+	Date string
+
+	// Body is the Markdown content between this heading and the next release heading, with leading and
+	// trailing blank lines removed. Link reference definitions whose label is a version or "Unreleased"
+	// are not part of any body, but other definitions, such as "[#21]: url", are kept.
+	Body string
+}
+
+// Changelog is a parsed changelog file.
+type Changelog struct {
+	// Preamble is the content before the first release heading, such as the title and introduction.
+	// An "Unreleased" section is not part of the preamble; see Unreleased.
+	Preamble string
+
+	// Unreleased is the body of an "## [Unreleased]" section, or "" if there is none.
+	Unreleased string
+
+	// Entries are the release entries, sorted in ascending order of version precedence.
+	Entries []Entry
+}
+
+// Parse parses the text of a changelog. It returns an error if two entries have the same version
+// precedence; versions that differ only in build metadata count as the same.
+func Parse(text string) (*Changelog, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var linkDefs []linkDefinition
+	c := &Changelog{}
+
+	var current *Entry
+	var versionLabels []string // the version text from the heading of each entry, such as "v1.0.0"
+	var body []string
+	inUnreleased, inFence := false, false
+	finish := func() {
+		content := strings.Trim(strings.Join(body, "\n"), "\n")
+		switch {
+		case current != nil:
+			current.Body = content
+			c.Entries = append(c.Entries, *current)
+		case inUnreleased:
+			c.Unreleased = content
+		default:
+			c.Preamble = content
+		}
+		body = body[:0]
+	}
+
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if def, ok := parseLinkDefinition(line); ok && isReleaseLabel(def.label) {
+				linkDefs = append(linkDefs, def)
+				continue
+			}
+			if headingText, ok := parseHeading(line); ok {
+				if e, label, ok := parseReleaseHeading(headingText); ok {
+					finish()
+					e.Heading = line
+					current, inUnreleased = &e, false
+					versionLabels = append(versionLabels, label)
+					continue
+				}
+				if isUnreleasedHeading(headingText) {
+					finish()
+					current, inUnreleased = nil, true
+					continue
+				}
+			}
+		}
+		body = append(body, line)
+	}
+	finish()
+
+	for i, e := range c.Entries {
+		if e.Link == "" {
+			e.Link = findLinkDefinition(linkDefs, versionLabels[i], e.Version)
+			c.Entries[i] = e
+		}
+	}
+	sort.SliceStable(c.Entries, func(i, j int) bool {
+		return c.Entries[i].Version.ComparePrecedence(c.Entries[j].Version) < 0
+	})
+	for i := 1; i < len(c.Entries); i++ {
+		if c.Entries[i-1].Version.ComparePrecedence(c.Entries[i].Version) == 0 {
+			return nil, fmt.Errorf("changelog has more than one entry for version %s", c.Entries[i].Version)
+		}
+	}
+	return c, nil
+}
+
+// Entry returns the entry whose version has the same precedence as v. The second return value is false
+// if there is no such entry.
+func (c *Changelog) Entry(v semver.Version) (Entry, bool) {
+	i := c.search(v)
+	if i < len(c.Entries) && c.Entries[i].Version.ComparePrecedence(v) == 0 {
+		return c.Entries[i], true
+	}
+	return Entry{}, false
+}
+
+// Between returns the entries for every version that is greater than from and less than or equal to to,
+// in ascending order. This is the set of release notes that a user upgrading from one version to another
+// would want to read; from and to do not need to have entries of their own.
+func (c *Changelog) Between(from, to semver.Version) []Entry {
+	start := c.search(from)
+	if start < len(c.Entries) && c.Entries[start].Version.ComparePrecedence(from) == 0 {
+		start++
+	}
+	end := c.search(to)
+	if end < len(c.Entries) && c.Entries[end].Version.ComparePrecedence(to) == 0 {
+		end++
+	}
+	if start >= end {
+		return nil
+	}
+	return c.Entries[start:end]
+}
+
+// search returns the index of the first entry whose version is not less than v.
+func (c *Changelog) search(v semver.Version) int {
+	return sort.Search(len(c.Entries), func(i int) bool {
+		return c.Entries[i].Version.ComparePrecedence(v) >= 0
+	})
+}
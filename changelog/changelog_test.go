@@ -0,0 +1,183 @@
+package changelog
+
+import (
+	"os"
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, s string) semver.Version {
+	v, err := semver.Parse(s)
+	require.NoError(t, err)
+	return v
+}
+
+func entryVersions(entries []Entry) []string {
+	var ret []string
+	for _, e := range entries {
+		ret = append(ret, e.Version.String())
+	}
+	return ret
+}
+
+func TestParseThisRepositoryChangelog(t *testing.T) {
+	data, err := os.ReadFile("../CHANGELOG.md")
+	require.NoError(t, err)
+	c, err := Parse(string(data))
+	require.NoError(t, err)
+
+	assert.Contains(t, c.Preamble, "# Change log")
+	assert.Equal(t, []string{"1.0.0", "1.0.1", "1.0.2", "1.0.3", "1.0.4"}, entryVersions(c.Entries))
+
+	latest := c.Entries[4]
+	assert.Equal(t, "https://github.com/launchdarkly/go-semver/compare/v1.0.3...v1.0.4", latest.Link)
+	assert.Equal(t, "2026-04-09", latest.Date)
+	assert.Contains(t, latest.Body, "### Bug Fixes")
+	assert.Contains(t, latest.Body, "Bump minimum go to 1.22")
+	assert.NotContains(t, latest.Body, "1.0.3")
+
+	e, ok := c.Entry(mustParse(t, "1.0.2"))
+	require.True(t, ok)
+	assert.Equal(t, "2021-01-20", e.Date)
+	assert.Equal(t, "", e.Link)
+	assert.Equal(t, "## [1.0.2] - 2021-01-20", e.Heading)
+
+	e, ok = c.Entry(mustParse(t, "1.0.0"))
+	require.True(t, ok)
+	assert.Equal(t, "Initial release.", e.Body)
+
+	assert.Equal(t, []string{"1.0.2", "1.0.3", "1.0.4"},
+		entryVersions(c.Between(mustParse(t, "1.0.1"), mustParse(t, "1.0.4"))))
+}
+
+const keepAChangelog = `# Changelog
+
+## [Unreleased]
+### Added
+- Something new.
+
+## [v2.0.0-rc.1] - 2024-03-01
+### Changed
+- Renamed things.
+
+## [1.1.0] – 2024-01-15
+### Added
+- A feature.
+
+` + "```" + `
+## [9.9.9] - not a real heading
+` + "```" + `
+
+### [1.0.1]
+- A fix.
+
+## 1.0.0
+Initial release.
+
+[Unreleased]: https://example.com/compare/v2.0.0-rc.1...HEAD
+[v2.0.0-rc.1]: https://example.com/compare/v1.1.0...v2.0.0-rc.1
+[1.1.0]: https://example.com/compare/v1.0.1...v1.1.0
+`
+
+func TestParseKeepAChangelog(t *testing.T) {
+	c, err := Parse(keepAChangelog)
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Changelog", c.Preamble)
+	assert.Equal(t, "### Added\n- Something new.", c.Unreleased)
+	assert.Equal(t, []string{"1.0.0", "1.0.1", "1.1.0", "2.0.0-rc.1"}, entryVersions(c.Entries))
+
+	assert.Equal(t, "Initial release.", c.Entries[0].Body, "link definitions are not part of a body")
+	assert.Equal(t, "", c.Entries[0].Date)
+	assert.Equal(t, "- A fix.", c.Entries[1].Body)
+	assert.Equal(t, "2024-01-15", c.Entries[2].Date)
+	assert.Equal(t, "https://example.com/compare/v1.0.1...v1.1.0", c.Entries[2].Link)
+	assert.Contains(t, c.Entries[2].Body, "## [9.9.9] - not a real heading", "headings in code blocks are ignored")
+	assert.Equal(t, "https://example.com/compare/v1.1.0...v2.0.0-rc.1", c.Entries[3].Link)
+}
+
+func TestParseKeepsOtherLinkDefinitionsInBody(t *testing.T) {
+	c, err := Parse("## 1.1.0\n- Fixed [#21].\n\n[#21]: https://example.com/issues/21\n\n" +
+		"## 1.0.0\n- See [docs].\n[docs]: https://example.com/docs\n[1.0.0]: https://example.com/v1.0.0\n" +
+		"[unreleased]: https://example.com/compare/v1.1.0...HEAD\n")
+	require.NoError(t, err)
+	assert.Equal(t, "- See [docs].\n[docs]: https://example.com/docs", c.Entries[0].Body)
+	assert.Equal(t, "https://example.com/v1.0.0", c.Entries[0].Link)
+	assert.Equal(t, "- Fixed [#21].\n\n[#21]: https://example.com/issues/21", c.Entries[1].Body)
+	assert.Equal(t, "", c.Entries[1].Link)
+}
+
+func TestParsePrefersExactLinkLabel(t *testing.T) {
+	for _, heading := range []string{"1.0.0", "v1.0.0"} {
+		t.Run(heading, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				c, err := Parse("## [" + heading + "]\n\n[1.0.0+b]: https://example.com/build\n" +
+					"[1.0.0]: https://example.com/1.0.0\n[v1.0.0]: https://example.com/v1.0.0\n")
+				require.NoError(t, err)
+				assert.Equal(t, "https://example.com/"+heading, c.Entries[0].Link)
+			}
+		})
+	}
+
+	c, err := Parse("## [V1.0.0]\n\n[1.0.0+b]: https://example.com/build\n[v1.0.0]: https://example.com/v1.0.0\n")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/build", c.Entries[0].Link, "first definition with the same precedence")
+}
+
+func TestParseMalformedHeadingsAndLinkDefinitions(t *testing.T) {
+	c, err := Parse("## [1.0.0 is not closed\n\n## 1.0.0\n[]: https://example.com\n[a[b]: https://example.com\n" +
+		"[1.0.0]:https://example.com\n[1.0.0]: \n")
+	require.NoError(t, err)
+	assert.Equal(t, "## [1.0.0 is not closed", c.Preamble)
+	require.Len(t, c.Entries, 1)
+	assert.Equal(t, "", c.Entries[0].Link)
+	assert.Equal(t, "[]: https://example.com\n[a[b]: https://example.com\n[1.0.0]:https://example.com\n[1.0.0]: ",
+		c.Entries[0].Body)
+}
+
+func TestParseDuplicateVersion(t *testing.T) {
+	_, err := Parse("## 1.0.0\n\n## [1.0.0+build]\n")
+	assert.Error(t, err)
+}
+
+func TestParseNoEntries(t *testing.T) {
+	c, err := Parse("# Changelog\n\n## Upcoming\nstuff\n")
+	require.NoError(t, err)
+	assert.Len(t, c.Entries, 0)
+	assert.Equal(t, "# Changelog\n\n## Upcoming\nstuff", c.Preamble)
+}
+
+func TestEntryNotFound(t *testing.T) {
+	c, err := Parse(keepAChangelog)
+	require.NoError(t, err)
+	_, ok := c.Entry(mustParse(t, "1.0.2"))
+	assert.False(t, ok)
+	_, ok = c.Entry(mustParse(t, "3.0.0"))
+	assert.False(t, ok)
+}
+
+func TestBetween(t *testing.T) {
+	c, err := Parse(keepAChangelog)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		from, to string
+		expected []string
+	}{
+		{"1.0.0", "1.1.0", []string{"1.0.1", "1.1.0"}},
+		{"0.1.0", "1.0.1", []string{"1.0.0", "1.0.1"}},
+		{"1.0.0", "1.0.9", []string{"1.0.1"}},
+		{"1.1.0", "3.0.0", []string{"2.0.0-rc.1"}},
+		{"1.1.0", "2.0.0-alpha", nil},
+		{"1.1.0", "1.1.0", nil},
+		{"1.1.0", "1.0.0", nil},
+	} {
+		t.Run(test.from+" to "+test.to, func(t *testing.T) {
+			assert.Equal(t, test.expected, entryVersions(c.Between(mustParse(t, test.from), mustParse(t, test.to))))
+		})
+	}
+}
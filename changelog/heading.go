@@ -0,0 +1,101 @@
+package changelog
+
+import (
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// parseHeading returns the text of an ATX-style Markdown heading ("# text" through "###### text").
+func parseHeading(line string) (string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level >= len(line) || line[level] != ' ' {
+		return "", false
+	}
+	return strings.TrimSpace(line[level:]), true
+}
+
+// parseReleaseHeading recognizes heading text such as "[1.0.4](url) (2026-04-09)", "[1.0.2] - 2021-01-20",
+// or "v1.0.0". It also returns the version as it appears in the heading, such as "v1.0.0".
+func parseReleaseHeading(text string) (Entry, string, bool) {
+	var e Entry
+	var versionText, rest string
+	if strings.HasPrefix(text, "[") {
+		end := strings.IndexByte(text, ']')
+		if end < 0 {
+			return Entry{}, "", false
+		}
+		versionText, rest = text[1:end], text[end+1:]
+		if strings.HasPrefix(rest, "(") {
+			if linkEnd := strings.IndexByte(rest, ')'); linkEnd >= 0 {
+				e.Link, rest = rest[1:linkEnd], rest[linkEnd+1:]
+			}
+		}
+	} else {
+		versionText, rest, _ = strings.Cut(text, " ")
+	}
+	v, ok := parseVersion(versionText)
+	if !ok {
+		return Entry{}, "", false
+	}
+	e.Version = v
+	e.Date = strings.Trim(rest, " \t-–—()")
+	return e, versionText, true
+}
+
+func isUnreleasedHeading(text string) bool {
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "["), "]")
+	return strings.EqualFold(text, "unreleased")
+}
+
+type linkDefinition struct {
+	label, url string
+}
+
+// parseLinkDefinition recognizes a Markdown link reference definition such as "[1.0.2]: https://...".
+func parseLinkDefinition(line string) (linkDefinition, bool) {
+	if !strings.HasPrefix(line, "[") {
+		return linkDefinition{}, false
+	}
+	label, url, ok := strings.Cut(line[1:], "]: ")
+	if !ok || label == "" || strings.ContainsAny(label, "[]") {
+		return linkDefinition{}, false
+	}
+	url = strings.TrimSpace(url)
+	return linkDefinition{label: label, url: url}, url != ""
+}
+
+// isReleaseLabel returns true if a link definition belongs to a release heading, rather than to a link in
+// the body of an entry such as "[#21]".
+func isReleaseLabel(label string) bool {
+	_, ok := parseVersion(label)
+	return ok || isUnreleasedHeading(label)
+}
+
+// findLinkDefinition returns the URL of the link definition for a release heading. A definition whose label
+// is the same as the version in the heading is preferred; otherwise, it is the first definition whose label
+// has the same precedence, such as "[v1.0.0]" for the heading "## 1.0.0".
+func findLinkDefinition(linkDefs []linkDefinition, versionLabel string, v semver.Version) string {
+	for _, def := range linkDefs {
+		if def.label == versionLabel {
+			return def.url
+		}
+	}
+	for _, def := range linkDefs {
+		if lv, ok := parseVersion(def.label); ok && lv.ComparePrecedence(v) == 0 {
+			return def.url
+		}
+	}
+	return ""
+}
+
+func parseVersion(s string) (semver.Version, bool) {
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		s = s[1:]
+	}
+	v, err := semver.Parse(s)
+	return v, err == nil
+}
@@ -0,0 +1,47 @@
+package semver
+
+import "slices"
+
+// Sort sorts versions of any Comparable type in ascending order of precedence. Versions with the same
+// precedence, such as "1.0.0+a" and "1.0.0+b", keep their original order.
+func Sort[T Comparable[T]](versions []T) {
+	slices.SortStableFunc(versions, func(a, b T) int { return a.ComparePrecedence(b) })
+}
+
+// Max returns the version with the highest precedence, or false if there are no versions. If several
+// versions have the highest precedence, it returns the first of them.
+func Max[T Comparable[T]](versions []T) (T, bool) {
+	return MaxSatisfying(versions, nil)
+}
+
+// Min returns the version with the lowest precedence, or false if there are no versions. If several
+// versions have the lowest precedence, it returns the first of them.
+func Min[T Comparable[T]](versions []T) (T, bool) {
+	return MinSatisfying(versions, nil)
+}
+
+// MaxSatisfying returns the version with the highest precedence that is in the specified set, or false
+// if there is none. A nil set includes every version.
+func MaxSatisfying[T Comparable[T]](versions []T, set Set[T]) (T, bool) {
+	return bestSatisfying(versions, set, 1)
+}
+
+// MinSatisfying returns the version with the lowest precedence that is in the specified set, or false
+// if there is none. A nil set includes every version.
+func MinSatisfying[T Comparable[T]](versions []T, set Set[T]) (T, bool) {
+	return bestSatisfying(versions, set, -1)
+}
+
+func bestSatisfying[T Comparable[T]](versions []T, set Set[T], direction int) (T, bool) {
+	var best T
+	found := false
+	for _, v := range versions {
+		if set != nil && !set.Contains(v) {
+			continue
+		}
+		if !found || v.ComparePrecedence(best)*direction > 0 {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
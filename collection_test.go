@@ -0,0 +1,70 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSort(t *testing.T) {
+	versions := []Version{
+		mustParse(t, "2.0.0"), mustParse(t, "1.0.0+b"), mustParse(t, "1.0.0-rc.1"), mustParse(t, "1.0.0+a"),
+		mustParse(t, "0.9.0"),
+	}
+	Sort(versions)
+	var sorted []string
+	for _, v := range versions {
+		sorted = append(sorted, v.String())
+	}
+	assert.Equal(t, []string{"0.9.0", "1.0.0-rc.1", "1.0.0+b", "1.0.0+a", "2.0.0"}, sorted)
+}
+
+func TestSortExtended(t *testing.T) {
+	versions := []ExtendedVersion{
+		mustParseExtended(t, "1.2.3.4"), mustParseExtended(t, "1.10"), mustParseExtended(t, "1.2.3"),
+	}
+	Sort(versions)
+	assert.Equal(t, "1.2.3", versions[0].String())
+	assert.Equal(t, "1.2.3.4", versions[1].String())
+	assert.Equal(t, "1.10", versions[2].String())
+}
+
+func TestMaxAndMin(t *testing.T) {
+	versions := []Version{
+		mustParse(t, "1.0.0"), mustParse(t, "3.0.0+a"), mustParse(t, "3.0.0+b"), mustParse(t, "0.1.0+a"),
+		mustParse(t, "0.1.0+b"), mustParse(t, "2.0.0"),
+	}
+	max, ok := Max(versions)
+	assert.True(t, ok)
+	assert.Equal(t, "3.0.0+a", max.String())
+	min, ok := Min(versions)
+	assert.True(t, ok)
+	assert.Equal(t, "0.1.0+a", min.String())
+
+	_, ok = Max([]Version(nil))
+	assert.False(t, ok)
+	_, ok = Min([]Version(nil))
+	assert.False(t, ok)
+}
+
+func TestMaxAndMinSatisfying(t *testing.T) {
+	versions := []Version{
+		mustParse(t, "1.2.0"), mustParse(t, "1.2.5"), mustParse(t, "1.3.0"), mustParse(t, "1.2.9-beta"),
+		mustParse(t, "2.0.0"),
+	}
+	r, err := ParseRange("~1.2")
+	assert.NoError(t, err)
+
+	max, ok := MaxSatisfying(versions, r)
+	assert.True(t, ok)
+	assert.Equal(t, "1.2.9-beta", max.String())
+	min, ok := MinSatisfying(versions, r)
+	assert.True(t, ok)
+	assert.Equal(t, "1.2.0", min.String())
+
+	none := NewRangeOf([]Constraint[Version]{GreaterThan(mustParse(t, "2.0.0"))})
+	_, ok = MaxSatisfying(versions, none)
+	assert.False(t, ok)
+	_, ok = MinSatisfying(versions, none)
+	assert.False(t, ok)
+}
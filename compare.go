@@ -62,16 +62,11 @@ func comparePrereleaseIdentifiers(prerel1, prerel2 string) int {
 
 		// each sub-identifier is compared numerically if both are numeric; if both are non-numeric,
 		// they're compared as strings; otherwise, the numeric one is the lesser one
-		var n1, n2, d int
-		var isNum1, isNum2 bool
-		n1, isNum1 = parsePositiveNumericString(identifier1)
-		n2, isNum2 = parsePositiveNumericString(identifier2)
+		var d int
+		isNum1 := isNumericIdentifier(identifier1)
+		isNum2 := isNumericIdentifier(identifier2)
 		if isNum1 && isNum2 {
-			if n1 < n2 {
-				d = -1
-			} else if n1 > n2 {
-				d = 1
-			}
+			d = compareNumericIdentifiers(identifier1, identifier2)
 		} else {
 			if isNum1 {
 				d = -1
@@ -0,0 +1,22 @@
+package semver
+
+// Comparable is implemented by any version type that has a total precedence order and can be formatted
+// with String. Version, ExtendedVersion and Packed all implement it, as do the version types of the other
+// versioning schemes in the subpackages of this module (debian.Version, rpm.Version and pep440.Version).
+//
+// ComparePrecedence returns -1 if the receiver has lower precedence than other, 1 if it has higher
+// precedence, or 0 if they have the same precedence.
+//
+// The generic functions and types in this package that are parameterized by a Comparable, such as Sort,
+// MaxSatisfying and RangeOf, work the same way for every versioning scheme, so code that only needs to
+// order versions can be written once for all of them.
+type Comparable[T any] interface {
+	ComparePrecedence(other T) int
+	String() string
+}
+
+var (
+	_ Comparable[Version]         = Version{}
+	_ Comparable[ExtendedVersion] = ExtendedVersion{}
+	_ Comparable[Packed]          = Packed{}
+)
@@ -0,0 +1,185 @@
+package semver
+
+// CompiledRange is a preprocessed form of a Range that is optimized for testing many versions against
+// the same range. It is created by Range.Compile.
+//
+// Each comparator set of the range is reduced to a single interval with at most one lower and one upper
+// bound, and empty intervals are discarded, so that a version is tested against at most two bounds per
+// set. The prerelease identifiers of each bound are parsed in advance. Matching never allocates.
+//
+// The zero value matches no versions.
+type CompiledRange struct {
+	intervals []compiledInterval
+}
+
+type compiledInterval struct {
+	lower, upper       compiledBound
+	hasLower, hasUpper bool
+}
+
+type compiledBound struct {
+	major, minor, patch int
+	prerelease          []prereleaseIdentifier
+	inclusive           bool
+}
+
+type prereleaseIdentifier struct {
+	text    string
+	numeric bool
+}
+
+// interval is the uncompiled form of compiledInterval, used while intersecting comparators.
+type interval struct {
+	lower, upper                   Version
+	hasLower, hasUpper             bool
+	lowerInclusive, upperInclusive bool
+}
+
+// Compile returns a CompiledRange that matches exactly the same versions as the Range.
+func (r Range) Compile() CompiledRange {
+	var ret CompiledRange
+	for _, set := range r.sets {
+		if iv, ok := intersectComparators(set); ok {
+			ret.intervals = append(ret.intervals, compiledInterval{
+				lower:    compileBound(iv.lower, iv.lowerInclusive),
+				upper:    compileBound(iv.upper, iv.upperInclusive),
+				hasLower: iv.hasLower,
+				hasUpper: iv.hasUpper,
+			})
+		}
+	}
+	return ret
+}
+
+// Matches returns true if the version is in the range.
+func (c CompiledRange) Matches(v Version) bool {
+	for i := range c.intervals {
+		iv := &c.intervals[i]
+		if iv.hasLower {
+			if d := compareToBound(v, &iv.lower); d < 0 || (d == 0 && !iv.lower.inclusive) {
+				continue
+			}
+		}
+		if iv.hasUpper {
+			if d := compareToBound(v, &iv.upper); d > 0 || (d == 0 && !iv.upper.inclusive) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// MatchesString parses a version string with ParseModeStrict and returns true if it is valid and is in
+// the range.
+func (c CompiledRange) MatchesString(s string) bool {
+	v, err := Parse(s)
+	return err == nil && c.Matches(v)
+}
+
+func intersectComparators(set []comparator) (interval, bool) {
+	var iv interval
+	for _, c := range set {
+		if c.op == opEQ || c.op == opGT || c.op == opGE {
+			inclusive := c.op != opGT
+			d := 1
+			if iv.hasLower {
+				d = c.version.ComparePrecedence(iv.lower)
+			}
+			if d > 0 || (d == 0 && !inclusive) {
+				iv.lower, iv.lowerInclusive, iv.hasLower = c.version, inclusive, true
+			}
+		}
+		if c.op == opEQ || c.op == opLT || c.op == opLE {
+			inclusive := c.op != opLT
+			d := -1
+			if iv.hasUpper {
+				d = c.version.ComparePrecedence(iv.upper)
+			}
+			if d < 0 || (d == 0 && !inclusive) {
+				iv.upper, iv.upperInclusive, iv.hasUpper = c.version, inclusive, true
+			}
+		}
+	}
+	if iv.hasUpper {
+		// nothing is lower than X.Y.Z-0 when X, Y, and Z are all zero
+		if !iv.upperInclusive && iv.upper == (Version{prerelease: lowestPrerelease}) {
+			return interval{}, false
+		}
+		if iv.hasLower {
+			d := iv.lower.ComparePrecedence(iv.upper)
+			if d > 0 || (d == 0 && !(iv.lowerInclusive && iv.upperInclusive)) {
+				return interval{}, false
+			}
+		}
+	}
+	return iv, true
+}
+
+func compileBound(v Version, inclusive bool) compiledBound {
+	b := compiledBound{major: v.major, minor: v.minor, patch: v.patch, inclusive: inclusive}
+	if v.prerelease != "" {
+		scanner := newSimpleASCIIScanner(v.prerelease)
+		for !scanner.eof() {
+			text, _ := scanner.readUntil(dotTerminator)
+			b.prerelease = append(b.prerelease, prereleaseIdentifier{text: text, numeric: isNumericIdentifier(text)})
+		}
+	}
+	return b
+}
+
+// compareToBound is equivalent to v.ComparePrecedence(Version(b)), using the preparsed identifiers.
+func compareToBound(v Version, b *compiledBound) int {
+	if v.major != b.major {
+		return compareInts(v.major, b.major)
+	}
+	if v.minor != b.minor {
+		return compareInts(v.minor, b.minor)
+	}
+	if v.patch != b.patch {
+		return compareInts(v.patch, b.patch)
+	}
+	if v.prerelease == "" {
+		if b.prerelease == nil {
+			return 0
+		}
+		return 1
+	}
+	if b.prerelease == nil {
+		return -1
+	}
+	scanner := newSimpleASCIIScanner(v.prerelease)
+	for _, id := range b.prerelease {
+		if scanner.eof() {
+			return -1
+		}
+		text, _ := scanner.readUntil(dotTerminator)
+		numeric := isNumericIdentifier(text)
+		switch {
+		case numeric && id.numeric:
+			if d := compareNumericIdentifiers(text, id.text); d != 0 {
+				return d
+			}
+		case numeric:
+			return -1
+		case id.numeric:
+			return 1
+		case text != id.text:
+			if text < id.text {
+				return -1
+			}
+			return 1
+		}
+	}
+	if scanner.eof() {
+		return 0
+	}
+	return 1
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	}
+	return 1 // only called when a != b
+}
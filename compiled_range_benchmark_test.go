@@ -0,0 +1,81 @@
+package semver
+
+import "testing"
+
+// These benchmarks compare Range.Contains, which evaluates each comparator with ComparePrecedence, to
+// CompiledRange.Matches for the same range and versions.
+
+var (
+	// use package-level variables so the compiler won't optimize away benchmark logic
+	benchmarkMatchResult bool
+)
+
+const benchmarkRange = ">=1.2.3-beta.2 <1.5.0 || ^2.1.0-rc.1 || ~3.0 >3.0.1"
+
+var benchmarkRangeVersions = []string{
+	"0.9.0",
+	"1.2.3-beta.10",
+	"1.4.9+build.7",
+	"2.1.0-rc.1.5",
+	"2.9.0",
+	"3.0.2",
+	"3.0.1-alpha",
+	"4.0.0",
+}
+
+func makeBenchmarkRangeVersions(b *testing.B) []Version {
+	versions := make([]Version, 0, len(benchmarkRangeVersions))
+	for _, s := range benchmarkRangeVersions {
+		v, err := Parse(s)
+		if err != nil {
+			b.Fatal(err)
+		}
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+func BenchmarkRangeContains(b *testing.B) {
+	r, _ := ParseRange(benchmarkRange)
+	versions := makeBenchmarkRangeVersions(b)
+	l := len(versions)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchmarkMatchResult = r.Contains(versions[n%l])
+	}
+}
+
+func BenchmarkCompiledRangeMatches(b *testing.B) {
+	r, _ := ParseRange(benchmarkRange)
+	c := r.Compile()
+	versions := makeBenchmarkRangeVersions(b)
+	l := len(versions)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchmarkMatchResult = c.Matches(versions[n%l])
+	}
+}
+
+func BenchmarkRangeContainsString(b *testing.B) {
+	r, _ := ParseRange(benchmarkRange)
+	l := len(benchmarkRangeVersions)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		v, err := Parse(benchmarkRangeVersions[n%l])
+		benchmarkMatchResult = err == nil && r.Contains(v)
+	}
+}
+
+func BenchmarkCompiledRangeMatchesString(b *testing.B) {
+	r, _ := ParseRange(benchmarkRange)
+	c := r.Compile()
+	l := len(benchmarkRangeVersions)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchmarkMatchResult = c.MatchesString(benchmarkRangeVersions[n%l])
+	}
+}
@@ -0,0 +1,82 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompiledRangeMatches(t *testing.T) {
+	for _, test := range rangeTests {
+		t.Run(test.rangeString+" matches "+test.version, func(t *testing.T) {
+			r, err := ParseRange(test.rangeString)
+			require.NoError(t, err)
+			c := r.Compile()
+			assert.Equal(t, test.expected, c.MatchesString(trimV(test.version)))
+		})
+	}
+}
+
+func TestCompiledRangeIsEquivalentToRange(t *testing.T) {
+	// Every range from rangeTests is checked against every version from rangeTests and compareTests,
+	// including versions that differ only in their prerelease identifiers.
+	versions := []Version{{}, {prerelease: "0"}, {prerelease: "0.0"}}
+	for _, test := range rangeTests {
+		v, err := Parse(trimV(test.version))
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+	for _, test := range compareTests {
+		versions = append(versions, test.v1, test.v2)
+	}
+	for _, test := range rangeTests {
+		r, err := ParseRange(test.rangeString)
+		require.NoError(t, err)
+		c := r.Compile()
+		for _, v := range versions {
+			assert.Equal(t, r.Contains(v), c.Matches(v), "range %q, version %s", test.rangeString, v)
+		}
+	}
+}
+
+func TestCompareToBound(t *testing.T) {
+	var versions []Version
+	for _, s := range []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-alpha.beta.1", "1.0.0-beta",
+		"1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-1", "1.0.0-1.a", "1.0.0-2", "1.0.0-10", "1.0.0", "1.0.1-0", "0.9.0"} {
+		v, err := Parse(s)
+		require.NoError(t, err)
+		versions = append(versions, v)
+	}
+	for _, v := range versions {
+		for _, bound := range versions {
+			b := compileBound(bound, false)
+			assert.Equal(t, v.ComparePrecedence(bound), compareToBound(v, &b), "%s vs %s", v, bound)
+		}
+	}
+}
+
+func TestCompiledRangeDiscardsEmptyIntervals(t *testing.T) {
+	for _, s := range []string{"<*", ">*", ">=2.0.0 <1.0.0", ">1.0.0 <1.0.0", "<0.0.0-0", "=1.0.0 =1.0.1"} {
+		r, err := ParseRange(s)
+		require.NoError(t, err)
+		assert.Len(t, r.Compile().intervals, 0, s)
+	}
+
+	r, err := ParseRange("<=1.0.0 >=1.0.0")
+	require.NoError(t, err)
+	assert.Len(t, r.Compile().intervals, 1)
+}
+
+func TestCompiledRangeMatchesStringInvalid(t *testing.T) {
+	r, err := ParseRange("*")
+	require.NoError(t, err)
+	c := r.Compile()
+	assert.True(t, c.MatchesString("1.0.0"))
+	assert.False(t, c.MatchesString("1.0"))
+	assert.False(t, c.MatchesString("v1.0.0"))
+}
+
+func TestZeroCompiledRangeMatchesNothing(t *testing.T) {
+	assert.False(t, CompiledRange{}.Matches(Version{}))
+}
@@ -0,0 +1,78 @@
+package semver
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testdata/semver-test-vectors.json is a language-neutral set of test vectors that other semver
+// implementations can also check themselves against; see the "description" property in the file.
+const testVectorsFile = "testdata/semver-test-vectors.json"
+
+type testVectors struct {
+	Valid []struct {
+		Input      string `json:"input"`
+		Major      int    `json:"major"`
+		Minor      int    `json:"minor"`
+		Patch      int    `json:"patch"`
+		Prerelease string `json:"prerelease"`
+		Build      string `json:"build"`
+	} `json:"valid"`
+	Invalid     []string `json:"invalid"`
+	Comparisons []struct {
+		V1     string `json:"v1"`
+		V2     string `json:"v2"`
+		Result int    `json:"result"`
+	} `json:"comparisons"`
+}
+
+func loadTestVectors(t *testing.T) testVectors {
+	data, err := os.ReadFile(testVectorsFile)
+	require.NoError(t, err)
+	var vectors testVectors
+	require.NoError(t, json.Unmarshal(data, &vectors))
+	require.NotEmpty(t, vectors.Valid)
+	require.NotEmpty(t, vectors.Invalid)
+	require.NotEmpty(t, vectors.Comparisons)
+	return vectors
+}
+
+func TestConformanceValid(t *testing.T) {
+	for _, test := range loadTestVectors(t).Valid {
+		t.Run(test.Input, func(t *testing.T) {
+			parsingShouldSucceed(Parse, test.Input, test.Major, test.Minor, test.Patch, test.Prerelease, test.Build)(t)
+
+			v, _ := Parse(test.Input)
+			assert.Equal(t, test.Input, v.String())
+		})
+	}
+}
+
+func TestConformanceInvalid(t *testing.T) {
+	for _, s := range loadTestVectors(t).Invalid {
+		t.Run(s, parsingShouldFail(Parse, s))
+	}
+}
+
+func TestConformanceComparisons(t *testing.T) {
+	for _, test := range loadTestVectors(t).Comparisons {
+		t.Run(test.V1+" vs "+test.V2, func(t *testing.T) {
+			v1, err := Parse(test.V1)
+			require.NoError(t, err)
+			v2, err := Parse(test.V2)
+			require.NoError(t, err)
+			assert.Equal(t, test.Result, v1.ComparePrecedence(v2))
+			assert.Equal(t, -test.Result, v2.ComparePrecedence(v1))
+
+			p1, err := Pack(v1)
+			require.NoError(t, err)
+			p2, err := Pack(v2)
+			require.NoError(t, err)
+			assert.Equal(t, test.Result, p1.ComparePrecedence(p2), "Packed")
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package conventional computes the next release version from commit messages that follow the
+// Conventional Commits 1.0.0 specification (https://www.conventionalcommits.org).
+//
+// The version calculation follows the default versioning strategy of release-please, which is the tool
+// used to release this repository, so that Go services can make the same decision in-process.
+package conventional
+
+import (
+	"strings"
+)
+
+// Commit is the parsed form of a Conventional Commit message.
+type Commit struct {
+	// Type is the commit type, such as "feat" or "fix", converted to lowercase.
+	Type string
+
+	// Scope is the optional scope that appears in parentheses after the type, or "" if there is none.
+	Scope string
+
+	// Description is the text after the colon in the header.
+	Description string
+
+	// Body is the free-form text between the header and the footers, or "" if there is none.
+	Body string
+
+	// Footers are the trailing "Token: value" or "Token #value" lines, in order.
+	Footers []Footer
+
+	// Breaking is true if the header contains "!" before the colon, or if there is a "BREAKING CHANGE"
+	// or "BREAKING-CHANGE" footer.
+	Breaking bool
+}
+
+// Footer is a single footer of a Conventional Commit message.
+type Footer struct {
+	// Token is the footer name, such as "Refs" or "BREAKING CHANGE".
+	Token string
+
+	// Value is the footer text, which may span multiple lines.
+	Value string
+}
+
+// ParseCommit attempts to parse a commit message as a Conventional Commit. The second return value is
+// false if the first line of the message is not a valid Conventional Commit header, in which case the
+// message does not contribute to any version change.
+func ParseCommit(message string) (Commit, bool) {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	header, rest, _ := strings.Cut(message, "\n")
+
+	var c Commit
+	if !parseHeader(strings.TrimSpace(header), &c) {
+		return Commit{}, false
+	}
+
+	// The body begins after one blank line, and the footers begin at the first line following a blank
+	// line that looks like a footer; everything from there on belongs to one footer or another.
+	lines := strings.Split(strings.Trim(rest, "\n"), "\n")
+	footerStart := len(lines)
+	for i, line := range lines {
+		if (i == 0 || strings.TrimSpace(lines[i-1]) == "") && isFooterLine(line) {
+			footerStart = i
+			break
+		}
+	}
+	c.Body = strings.TrimSpace(strings.Join(lines[:footerStart], "\n"))
+	for _, line := range lines[footerStart:] {
+		if token, value, ok := parseFooterLine(line); ok {
+			c.Footers = append(c.Footers, Footer{Token: token, Value: value})
+		} else if n := len(c.Footers); n > 0 {
+			c.Footers[n-1].Value += "\n" + line
+		}
+	}
+	for i, f := range c.Footers {
+		c.Footers[i].Value = strings.TrimSpace(f.Value)
+		if f.Token == "BREAKING CHANGE" || f.Token == "BREAKING-CHANGE" {
+			c.Breaking = true
+		}
+	}
+	return c, true
+}
+
+// parseHeader parses "type(scope)!: description", where the scope and "!" are optional.
+func parseHeader(header string, c *Commit) bool {
+	prefix, description, ok := strings.Cut(header, ":")
+	if !ok {
+		return false
+	}
+	c.Description = strings.TrimSpace(description)
+	if c.Description == "" || !strings.HasPrefix(description, " ") {
+		return false
+	}
+	if strings.HasSuffix(prefix, "!") {
+		c.Breaking = true
+		prefix = prefix[:len(prefix)-1]
+	}
+	if open := strings.IndexByte(prefix, '('); open >= 0 {
+		if !strings.HasSuffix(prefix, ")") {
+			return false
+		}
+		c.Scope = prefix[open+1 : len(prefix)-1]
+		if c.Scope == "" || strings.ContainsAny(c.Scope, "()") {
+			return false
+		}
+		prefix = prefix[:open]
+	}
+	if prefix == "" || !isToken(prefix, false) {
+		return false
+	}
+	c.Type = strings.ToLower(prefix)
+	return true
+}
+
+func isFooterLine(line string) bool {
+	_, _, ok := parseFooterLine(line)
+	return ok
+}
+
+// parseFooterLine recognizes "Token: value" and "Token #value", where the token is a word that may
+// contain hyphens; "BREAKING CHANGE" is the only token allowed to contain a space.
+func parseFooterLine(line string) (token, value string, ok bool) {
+	if rest, found := strings.CutPrefix(line, "BREAKING CHANGE: "); found {
+		return "BREAKING CHANGE", rest, true
+	}
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == ':' && i+1 < len(line) && line[i+1] == ' ':
+			token, value = line[:i], line[i+2:]
+		case line[i] == ' ' && i+1 < len(line) && line[i+1] == '#':
+			token, value = line[:i], line[i+1:]
+		default:
+			continue
+		}
+		return token, value, token != "" && isToken(token, true)
+	}
+	return "", "", false
+}
+
+func isToken(s string, allowHyphen bool) bool {
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') ||
+			(allowHyphen && ch == '-')) {
+			return false
+		}
+	}
+	return true
+}
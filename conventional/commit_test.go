@@ -0,0 +1,100 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommitHeader(t *testing.T) {
+	for _, test := range []struct {
+		message  string
+		expected Commit
+	}{
+		{"feat: add thing", Commit{Type: "feat", Description: "add thing"}},
+		{"FIX: Something", Commit{Type: "fix", Description: "Something"}},
+		{"fix(parser): handle zero", Commit{Type: "fix", Scope: "parser", Description: "handle zero"}},
+		{"feat!: drop support", Commit{Type: "feat", Description: "drop support", Breaking: true}},
+		{"feat(api)!: rename", Commit{Type: "feat", Scope: "api", Description: "rename", Breaking: true}},
+		{"chore(deps): bump x: y", Commit{Type: "chore", Scope: "deps", Description: "bump x: y"}},
+		{"  docs: trimmed  \n", Commit{Type: "docs", Description: "trimmed"}},
+	} {
+		t.Run(test.message, func(t *testing.T) {
+			c, ok := ParseCommit(test.message)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, c)
+		})
+	}
+}
+
+func TestParseCommitInvalidHeader(t *testing.T) {
+	for _, message := range []string{
+		"",
+		"add a thing",
+		"feat add thing",
+		"feat:add thing",
+		"feat: ",
+		": add thing",
+		"feat(): add thing",
+		"feat(api: add thing",
+		"feat(a)(b): add thing",
+		"feat thing: add thing",
+		"Merge branch 'main' into feature",
+		"\nfeat: header must be on the first line",
+	} {
+		t.Run(message, func(t *testing.T) {
+			_, ok := ParseCommit(message)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestParseCommitBodyAndFooters(t *testing.T) {
+	t.Run("body only", func(t *testing.T) {
+		c, ok := ParseCommit("fix: x\n\nfirst paragraph\n\nsecond paragraph: here\n")
+		require.True(t, ok)
+		assert.Equal(t, "first paragraph\n\nsecond paragraph: here", c.Body)
+		assert.Len(t, c.Footers, 0)
+	})
+
+	t.Run("body and footers", func(t *testing.T) {
+		c, ok := ParseCommit("fix: x\n\nsome body\n\nReviewed-by: Z\nRefs #133\n")
+		require.True(t, ok)
+		assert.Equal(t, "some body", c.Body)
+		assert.Equal(t, []Footer{{"Reviewed-by", "Z"}, {"Refs", "#133"}}, c.Footers)
+		assert.False(t, c.Breaking)
+	})
+
+	t.Run("footers without body", func(t *testing.T) {
+		c, ok := ParseCommit("fix: x\n\nRefs: #1")
+		require.True(t, ok)
+		assert.Equal(t, "", c.Body)
+		assert.Equal(t, []Footer{{"Refs", "#1"}}, c.Footers)
+	})
+
+	t.Run("multi-line footer", func(t *testing.T) {
+		c, ok := ParseCommit("feat: x\n\nBREAKING CHANGE: the config\nformat changed\nRefs: #2")
+		require.True(t, ok)
+		assert.Equal(t, []Footer{{"BREAKING CHANGE", "the config\nformat changed"}, {"Refs", "#2"}}, c.Footers)
+		assert.True(t, c.Breaking)
+	})
+
+	t.Run("hyphenated breaking change token", func(t *testing.T) {
+		c, ok := ParseCommit("fix: x\r\n\r\nBREAKING-CHANGE: yes\r\n")
+		require.True(t, ok)
+		assert.True(t, c.Breaking)
+	})
+
+	t.Run("breaking change must be uppercase", func(t *testing.T) {
+		c, ok := ParseCommit("fix: x\n\nbreaking change: no")
+		require.True(t, ok)
+		assert.False(t, c.Breaking)
+	})
+
+	t.Run("breaking change in body is not a footer", func(t *testing.T) {
+		c, ok := ParseCommit("fix: x\n\nthis mentions\nBREAKING CHANGE: inline")
+		require.True(t, ok)
+		assert.False(t, c.Breaking)
+	})
+}
@@ -0,0 +1,56 @@
+package conventional
+
+import (
+	"github.com/launchdarkly/go-semver"
+)
+
+// Options controls how commits are translated into a version bump. The zero value matches the default
+// behavior of release-please.
+type Options struct {
+	// BumpMinorPreMajor corresponds to release-please's "bump-minor-pre-major" option: if the current
+	// major version is 0, a breaking change bumps the minor version instead of the major version.
+	BumpMinorPreMajor bool
+
+	// BumpPatchForMinorPreMajor corresponds to release-please's "bump-patch-for-minor-pre-major" option:
+	// if the current major version is 0, a new feature bumps the patch version instead of the minor version.
+	BumpPatchForMinorPreMajor bool
+}
+
+// CommitBump returns the kind of version change implied by a single commit, without regard to the
+// current version. A breaking change implies semver.BumpMajor, a "feat" commit implies semver.BumpMinor,
+// and "fix", "perf", "revert" and "deps" commits imply semver.BumpPatch. Other types, such as "docs" or
+// "chore", return semver.BumpNone because release-please does not consider them releasable.
+func CommitBump(c Commit) semver.BumpKind {
+	if c.Breaking {
+		return semver.BumpMajor
+	}
+	switch c.Type {
+	case "feat":
+		return semver.BumpMinor
+	case "fix", "perf", "revert", "deps":
+		return semver.BumpPatch
+	default:
+		return semver.BumpNone
+	}
+}
+
+// NextVersion returns the recommended version to release after current, given the messages of all
+// commits made since current was released, along with the kind of bump that was applied. Messages that
+// are not Conventional Commits are ignored. If no commit is releasable, it returns current and
+// semver.BumpNone.
+func NextVersion(current semver.Version, messages []string, opts Options) (semver.Version, semver.BumpKind) {
+	kind := semver.BumpNone
+	for _, m := range messages {
+		if c, ok := ParseCommit(m); ok {
+			kind = max(kind, CommitBump(c))
+		}
+	}
+	if current.GetMajor() == 0 {
+		if kind == semver.BumpMajor && opts.BumpMinorPreMajor {
+			kind = semver.BumpMinor
+		} else if kind == semver.BumpMinor && opts.BumpPatchForMinorPreMajor {
+			kind = semver.BumpPatch
+		}
+	}
+	return current.Bump(kind), kind
+}
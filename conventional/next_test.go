@@ -0,0 +1,69 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitBump(t *testing.T) {
+	for message, expected := range map[string]semver.BumpKind{
+		"feat: a":                       semver.BumpMinor,
+		"fix: a":                        semver.BumpPatch,
+		"perf: a":                       semver.BumpPatch,
+		"revert: a":                     semver.BumpPatch,
+		"deps: a":                       semver.BumpPatch,
+		"docs: a":                       semver.BumpNone,
+		"chore: a":                      semver.BumpNone,
+		"chore!: a":                     semver.BumpMajor,
+		"docs: a\n\nBREAKING CHANGE: b": semver.BumpMajor,
+	} {
+		c, ok := ParseCommit(message)
+		require.True(t, ok)
+		assert.Equal(t, expected, CommitBump(c), message)
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		current  string
+		messages []string
+		opts     Options
+		expected string
+		kind     semver.BumpKind
+	}{
+		{"no commits", "1.0.4", nil, Options{}, "1.0.4", semver.BumpNone},
+		{"only non-releasable", "1.0.4", []string{"docs: a", "chore: b", "not conventional"}, Options{},
+			"1.0.4", semver.BumpNone},
+		{"fix", "1.0.4", []string{"chore: a", "fix: b"}, Options{}, "1.0.5", semver.BumpPatch},
+		{"feat wins over fix", "1.0.4", []string{"fix: a", "feat: b", "fix: c"}, Options{},
+			"1.1.0", semver.BumpMinor},
+		{"breaking wins over feat", "1.0.4", []string{"feat: a", "fix!: b"}, Options{}, "2.0.0", semver.BumpMajor},
+		{"breaking footer", "1.0.4", []string{"refactor: a\n\nBREAKING CHANGE: b"}, Options{},
+			"2.0.0", semver.BumpMajor},
+		{"pre-major breaking", "0.3.1", []string{"feat!: a"}, Options{}, "1.0.0", semver.BumpMajor},
+		{"pre-major breaking with bump-minor-pre-major", "0.3.1", []string{"feat!: a"},
+			Options{BumpMinorPreMajor: true}, "0.4.0", semver.BumpMinor},
+		{"pre-major feat with bump-minor-pre-major", "0.3.1", []string{"feat: a"},
+			Options{BumpMinorPreMajor: true}, "0.4.0", semver.BumpMinor},
+		{"pre-major feat with bump-patch-for-minor-pre-major", "0.3.1", []string{"feat: a"},
+			Options{BumpPatchForMinorPreMajor: true}, "0.3.2", semver.BumpPatch},
+		{"pre-major breaking with both options", "0.3.1", []string{"feat!: a"},
+			Options{BumpMinorPreMajor: true, BumpPatchForMinorPreMajor: true}, "0.4.0", semver.BumpMinor},
+		{"options have no effect after 1.0", "1.3.1", []string{"feat!: a"},
+			Options{BumpMinorPreMajor: true, BumpPatchForMinorPreMajor: true}, "2.0.0", semver.BumpMajor},
+		{"build metadata is dropped", "1.0.4+abc", []string{"fix: a"}, Options{}, "1.0.5", semver.BumpPatch},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			current, err := semver.Parse(test.current)
+			require.NoError(t, err)
+			next, kind := NextVersion(current, test.messages, test.opts)
+			assert.Equal(t, test.expected, next.String())
+			assert.Equal(t, test.kind, kind)
+		})
+	}
+}
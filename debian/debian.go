@@ -0,0 +1,208 @@
+// Package debian implements the version scheme used by Debian packages (and by derivatives such as Ubuntu),
+// with the same ordering rules as dpkg.
+//
+// A Debian version has the form "[epoch:]upstream_version[-debian_revision]", for instance
+// "1:2.30-0ubuntu2.1". See https://www.debian.org/doc/debian-policy/ch-controlfields.html#version.
+//
+// Like the semver package, this package does not use regular expressions, and neither parsing nor
+// comparison causes any heap allocations.
+package debian
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed Debian package version.
+//
+// Versions are compared with ComparePrecedence, which implements semver.Comparable. Two versions that are
+// spelled differently may have the same precedence: for instance, "1.0", "0:1.0", "1.00" and "1.0-0" are
+// all equivalent.
+type Version struct {
+	epoch    int
+	upstream string
+	revision string
+}
+
+var errInvalidVersion = errors.New("invalid Debian version")
+
+// Parse attempts to parse a string into a Version.
+//
+// The upstream version must start with a digit, and may contain only alphanumerics and the characters
+// ".", "+", "~", "-" and ":". The Debian revision, if any, is whatever follows the last hyphen, and may
+// contain only alphanumerics and the characters ".", "+" and "~". The epoch, if any, is whatever precedes
+// the first colon, and must be a non-negative integer.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and Version{} as the first.
+func Parse(s string) (Version, error) {
+	var v Version
+	rest := s
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		epoch, ok := parseEpoch(s[:i])
+		if !ok {
+			return Version{}, errInvalidVersion
+		}
+		v.epoch = epoch
+		rest = s[i+1:]
+	}
+	v.upstream = rest
+	if i := strings.LastIndexByte(rest, '-'); i >= 0 {
+		v.upstream, v.revision = rest[:i], rest[i+1:]
+		if v.revision == "" || !everyChar(v.revision, isRevisionChar) {
+			return Version{}, errInvalidVersion
+		}
+	}
+	if v.upstream == "" || !isDigit(v.upstream[0]) || !everyChar(v.upstream, isUpstreamChar) {
+		return Version{}, errInvalidVersion
+	}
+	return v, nil
+}
+
+// Epoch returns the epoch of the version, or zero if it did not have one.
+func (v Version) Epoch() int {
+	return v.epoch
+}
+
+// Upstream returns the upstream version, for instance "2.30" in "1:2.30-0ubuntu2.1".
+func (v Version) Upstream() string {
+	return v.upstream
+}
+
+// Revision returns the Debian revision, for instance "0ubuntu2.1" in "1:2.30-0ubuntu2.1", or an empty
+// string if the version did not have one.
+func (v Version) Revision() string {
+	return v.revision
+}
+
+// String returns the version in the same form it was parsed from, except that an epoch of zero is omitted.
+func (v Version) String() string {
+	s := v.upstream
+	if v.revision != "" {
+		s += "-" + v.revision
+	}
+	if v.epoch != 0 {
+		s = strconv.Itoa(v.epoch) + ":" + s
+	}
+	return s
+}
+
+// ComparePrecedence compares this Version to another Version with the same rules as dpkg. It returns -1
+// if v has lower precedence than other, 1 if v has higher precedence, or 0 if the same.
+//
+// Epochs are compared numerically. The upstream versions, and then the Debian revisions, are each compared
+// by splitting them into alternating runs of non-digits and digits: runs of digits are compared
+// numerically, and runs of non-digits are compared character by character, with letters sorting before
+// any other characters, and "~" sorting before anything, even the end of the string. So "1.0~rc1" is
+// lower than "1.0", which is lower than "1.0+b1" and "1.0.1". A missing Debian revision is equivalent
+// to "0".
+func (v Version) ComparePrecedence(other Version) int {
+	if v.epoch != other.epoch {
+		if v.epoch < other.epoch {
+			return -1
+		}
+		return 1
+	}
+	if result := compareFragments(v.upstream, other.upstream); result != 0 {
+		return result
+	}
+	return compareFragments(v.revision, other.revision)
+}
+
+// compareFragments is the equivalent of dpkg's verrevcmp function.
+func compareFragments(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for (i < len(a) && !isDigit(a[i])) || (j < len(b) && !isDigit(b[j])) {
+			ac, bc := charOrder(a, i), charOrder(b, j)
+			if ac != bc {
+				return sign(ac - bc)
+			}
+			i++
+			j++
+		}
+		for i < len(a) && a[i] == '0' {
+			i++
+		}
+		for j < len(b) && b[j] == '0' {
+			j++
+		}
+		firstDiff := 0
+		for i < len(a) && isDigit(a[i]) && j < len(b) && isDigit(b[j]) {
+			if firstDiff == 0 {
+				firstDiff = int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+		}
+		if i < len(a) && isDigit(a[i]) {
+			return 1
+		}
+		if j < len(b) && isDigit(b[j]) {
+			return -1
+		}
+		if firstDiff != 0 {
+			return sign(firstDiff)
+		}
+	}
+	return 0
+}
+
+// charOrder returns the sort weight of the character at index i in a run of non-digits, treating the end
+// of the string as a character that sorts after "~" and before everything else.
+func charOrder(s string, i int) int {
+	if i >= len(s) {
+		return 0
+	}
+	ch := s[i]
+	switch {
+	case isDigit(ch):
+		return 0
+	case isLetter(ch):
+		return int(ch)
+	case ch == '~':
+		return -1
+	default:
+		return int(ch) + 256
+	}
+}
+
+func parseEpoch(s string) (int, bool) {
+	if s == "" || !everyChar(s, isDigit) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+func sign(n int) int {
+	if n < 0 {
+		return -1
+	}
+	return 1 // only called when n != 0
+}
+
+func everyChar(s string, fn func(byte) bool) bool {
+	for i := 0; i < len(s); i++ {
+		if !fn(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isUpstreamChar(ch byte) bool {
+	return isDigit(ch) || isLetter(ch) || ch == '.' || ch == '+' || ch == '~' || ch == '-' || ch == ':'
+}
+
+func isRevisionChar(ch byte) bool {
+	return isDigit(ch) || isLetter(ch) || ch == '.' || ch == '+' || ch == '~'
+}
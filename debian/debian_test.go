@@ -0,0 +1,127 @@
+package debian
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ semver.Comparable[Version] = Version{}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		input, upstream, revision string
+		epoch                     int
+	}{
+		{"1.0", "1.0", "", 0},
+		{"2.30-0ubuntu2.1", "2.30", "0ubuntu2.1", 0},
+		{"1:2.30-0ubuntu2.1", "2.30", "0ubuntu2.1", 1},
+		{"0:1.0", "1.0", "", 0},
+		{"1.2.3-4-5", "1.2.3-4", "5", 0},
+		{"2:1.0~rc1+dfsg-1~bpo11+1", "1.0~rc1+dfsg", "1~bpo11+1", 2},
+		{"1:2:3.0-1", "2:3.0", "1", 1},
+		{"7", "7", "", 0},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.epoch, v.Epoch())
+			assert.Equal(t, test.upstream, v.Upstream())
+			assert.Equal(t, test.revision, v.Revision())
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", "a1.0", ".1", "1.0-", "-1", ":1.0", "a:1.0", "-1:1.0", "1:", "1.0 ", " 1.0", "1.0_1",
+		"1.0-a:b", "1.0-1_2", "99999999999999999999:1.0", "1.0é",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := Parse(s)
+			assert.Error(t, err)
+			assert.Equal(t, Version{}, v)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	for input, expected := range map[string]string{
+		"1.0":               "1.0",
+		"0:1.0-1":           "1.0-1",
+		"1:2.30-0ubuntu2.1": "1:2.30-0ubuntu2.1",
+		"1.2.3-4-5":         "1.2.3-4-5",
+	} {
+		v, err := Parse(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, v.String())
+	}
+}
+
+func TestComparePrecedence(t *testing.T) {
+	// Most of these are from the dpkg test suite (lib/dpkg/t/t-version.c)
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"0:0-0", "0:0-0", 0},
+		{"0:0-00", "0:00-0", 0},
+		{"1:2-3", "1:2-3", 0},
+		{"1.0", "0:1.0", 0},
+		{"1.0", "1.0-0", 0},
+		{"1.0", "1.00", 0},
+		{"0:0-0", "1:0-0", -1},
+		{"0:0-0", "0:1-0", -1},
+		{"0:0-0", "0:0-1", -1},
+		{"0:0-9", "0:0-10", -1},
+		{"0:9-0", "0:10-0", -1},
+		{"9:0-0", "10:0-0", -1},
+		{"1:0-0", "0:9999-9999", 1},
+		{"0:0-0", "0:0-00", 0},
+		{"0:0a-0", "0:0b-0", -1},
+		{"0:0a-0", "0:0A-0", 1},
+		{"0:0Z-0", "0:0a-0", -1},
+		{"0:0a-0", "0:0-0", 1},
+		{"0:0~-0", "0:0-0", -1},
+		{"0:0~~-0", "0:0~-0", -1},
+		{"0:0~~a-0", "0:0~~-0", 1},
+		{"0:0~a-0", "0:0~~a-0", 1},
+		{"0:0.-0", "0:0a-0", 1},
+		{"0:0+-0", "0:0.-0", -1},
+		{"0:0.0-0", "0:0+0-0", 1},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0", "1.0+b1", -1},
+		{"1.0+b1", "1.0.1", -1},
+		{"1.0~rc1-1", "1.0-1", -1},
+		{"1.0-1~bpo1", "1.0-1", -1},
+		{"2.30-0ubuntu2.1", "2.30-0ubuntu2", 1},
+		{"2.30-0ubuntu2.1", "2.30-0ubuntu10", -1},
+		{"1:2.30-0ubuntu2.1", "2.31-1", 1},
+		{"1.2.3-4-5", "1.2.3-4", 1},
+		{"7.0001", "7.1", 0},
+		{"1a1", "1aa", -1},
+		{"1.0a", "1.0.1", -1},
+		{"1.18446744073709551616", "1.18446744073709551617", -1},
+	} {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			a, err := Parse(test.a)
+			require.NoError(t, err)
+			b, err := Parse(test.b)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, a.ComparePrecedence(b))
+			assert.Equal(t, -test.expected, b.ComparePrecedence(a))
+		})
+	}
+}
+
+func TestParseAndCompareDoNotAllocate(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		a, _ := Parse("1:2.30-0ubuntu2.1")
+		b, _ := Parse("1:2.30-0ubuntu2")
+		_ = a.ComparePrecedence(b)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
@@ -0,0 +1,142 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ExtendedVersion is a version with any number of numeric release components, such as the four-component
+// "10.0.19041.1" used for Windows binaries. It may also have prerelease and build components, with the same
+// syntax and precedence rules as a Version.
+//
+// An ExtendedVersion with three or fewer numeric components can be converted to a Version with
+// ToVersion.
+type ExtendedVersion struct {
+	components []int
+	prerelease string
+	build      string
+}
+
+// ParseExtended attempts to parse a string into an ExtendedVersion. The string must contain one or more
+// numeric components separated by periods, optionally followed by a prerelease and/or build component as
+// in a semantic version ("1.2.3.4-beta.1+build"). As in a semantic version, numeric components must not
+// have leading zeroes.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and ExtendedVersion{} as the
+// first.
+func ParseExtended(s string) (ExtendedVersion, error) {
+	scanner := newSimpleASCIIScanner(s)
+
+	var result ExtendedVersion
+	term := int8('.')
+	for term == '.' {
+		var n int
+		var ok bool
+		n, term, ok = requirePositiveIntegerComponent(&scanner, dotOrHyphenOrPlusTerminator)
+		if !ok {
+			return ExtendedVersion{}, errInvalidSemver
+		}
+		result.components = append(result.components, n)
+	}
+
+	if term == '-' {
+		result.prerelease, term = scanner.readUntil(plusTerminator)
+		if result.prerelease == "" || term == scannerNonASCII || !validatePrerelease(result.prerelease) {
+			return ExtendedVersion{}, errInvalidSemver
+		}
+	}
+
+	if term == '+' {
+		result.build, term = scanner.readUntil(noTerminator)
+		if result.build == "" || term == scannerNonASCII || !validateBuild(result.build) {
+			return ExtendedVersion{}, errInvalidSemver
+		}
+	}
+
+	return result, nil
+}
+
+// Extended returns the ExtendedVersion equivalent to this Version, with three numeric components.
+func (v Version) Extended() ExtendedVersion {
+	return ExtendedVersion{components: []int{v.major, v.minor, v.patch}, prerelease: v.prerelease, build: v.build}
+}
+
+// Len returns the number of numeric components.
+func (v ExtendedVersion) Len() int {
+	return len(v.components)
+}
+
+// GetComponent returns the numeric component at the specified zero-based index, or zero if the index is
+// out of range; this is consistent with the rule that missing components are equivalent to zero.
+func (v ExtendedVersion) GetComponent(index int) int {
+	if index < 0 || index >= len(v.components) {
+		return 0
+	}
+	return v.components[index]
+}
+
+// GetPrerelease returns the prerelease version component, or "" if there is none.
+func (v ExtendedVersion) GetPrerelease() string {
+	return v.prerelease
+}
+
+// GetBuild returns the build version component, or "" if there is none.
+func (v ExtendedVersion) GetBuild() string {
+	return v.build
+}
+
+// String returns the string form of the version, with the same number of numeric components it was
+// parsed with.
+func (v ExtendedVersion) String() string {
+	buf := make([]byte, 0, 4*len(v.components)+len(v.prerelease)+len(v.build)+2)
+	for i, n := range v.components {
+		if i > 0 {
+			buf = append(buf, '.')
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+	}
+	if v.prerelease != "" {
+		buf = append(append(buf, '-'), v.prerelease...)
+	}
+	if v.build != "" {
+		buf = append(append(buf, '+'), v.build...)
+	}
+	return string(buf)
+}
+
+// ComparePrecedence compares this ExtendedVersion to another according to the same rules as
+// Version.ComparePrecedence, except that the numeric components are compared pairwise from left to right
+// and a missing component is equivalent to zero. So "1.2" and "1.2.0.0" have the same precedence, and
+// "1.2.0.1" is higher than both. It returns -1 if v has lower precedence than other, 1 if v has higher
+// precedence, or 0 if the same.
+func (v ExtendedVersion) ComparePrecedence(other ExtendedVersion) int {
+	n := max(len(v.components), len(other.components))
+	for i := 0; i < n; i++ {
+		a, b := v.GetComponent(i), other.GetComponent(i)
+		if a < b {
+			return -1
+		}
+		if a > b {
+			return 1
+		}
+	}
+	return Version{prerelease: v.prerelease}.ComparePrecedence(Version{prerelease: other.prerelease})
+}
+
+// ToVersion converts the ExtendedVersion to a Version. Missing minor and patch components are treated as
+// zero, so "2.1" becomes "2.1.0". If there are more than three components, as in "1.2.3.0", ToVersion
+// returns an error.
+func (v ExtendedVersion) ToVersion() (Version, error) {
+	if len(v.components) > 3 {
+		return Version{}, fmt.Errorf(
+			"version %q cannot be converted to a semantic version because it has more than 3 numeric components",
+			v.String())
+	}
+	return Version{
+		major:      v.GetComponent(0),
+		minor:      v.GetComponent(1),
+		patch:      v.GetComponent(2),
+		prerelease: v.prerelease,
+		build:      v.build,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExtended(t *testing.T) {
+	for _, test := range []struct {
+		input      string
+		components []int
+		prerelease string
+		build      string
+	}{
+		{"1", []int{1}, "", ""},
+		{"1.2", []int{1, 2}, "", ""},
+		{"1.2.3", []int{1, 2, 3}, "", ""},
+		{"10.0.19041.1", []int{10, 0, 19041, 1}, "", ""},
+		{"1.2.3.4.5.6", []int{1, 2, 3, 4, 5, 6}, "", ""},
+		{"1.2.3.4-beta.1", []int{1, 2, 3, 4}, "beta.1", ""},
+		{"1.2.3.4+build.5", []int{1, 2, 3, 4}, "", "build.5"},
+		{"1.2.3.4-rc-1+b.01", []int{1, 2, 3, 4}, "rc-1", "b.01"},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := ParseExtended(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, len(test.components), v.Len())
+			for i, n := range test.components {
+				assert.Equal(t, n, v.GetComponent(i))
+			}
+			assert.Equal(t, 0, v.GetComponent(-1))
+			assert.Equal(t, 0, v.GetComponent(len(test.components)))
+			assert.Equal(t, test.prerelease, v.GetPrerelease())
+			assert.Equal(t, test.build, v.GetBuild())
+			assert.Equal(t, test.input, v.String())
+		})
+	}
+}
+
+func TestParseExtendedInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", ".", "1.", ".1", "1..2", "1.2.3.", "01.2", "1.2.03.4", "1.2.3.4-", "1.2.3.4-01", "1.2.3.4+",
+		"1.2.3.4-a..b", "v1.2.3.4", "1.2.x.4", "1.2.3.4🔥", "1.2.3.4-a🔥",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := ParseExtended(s)
+			assert.Error(t, err)
+			assert.Equal(t, ExtendedVersion{}, v)
+		})
+	}
+}
+
+func TestExtendedComparePrecedence(t *testing.T) {
+	for _, test := range []struct {
+		v1, v2 string
+		result int
+	}{
+		{"1.2.3.4", "1.2.3.4", 0},
+		{"1.2", "1.2.0.0", 0},
+		{"1", "1.0.0.0.0.0", 0},
+		{"1.2.0.1", "1.2", 1},
+		{"1.2.3.4", "1.2.3.5", -1},
+		{"1.2.3.10", "1.2.3.9", 1},
+		{"1.3", "1.2.9.9", 1},
+		{"1.2.3.4-beta", "1.2.3.4", -1},
+		{"1.2.3.4-beta", "1.2.3.4.0-beta", 0},
+		{"1.2.3.4-beta.2", "1.2.3.4-beta.11", -1},
+		{"1.2.3.4+a", "1.2.3.4+b", 0},
+		{"1.2.3.4-rc", "1.2.3.3", 1},
+	} {
+		v1, err := ParseExtended(test.v1)
+		require.NoError(t, err)
+		v2, err := ParseExtended(test.v2)
+		require.NoError(t, err)
+		assert.Equal(t, test.result, v1.ComparePrecedence(v2), "%s vs %s", test.v1, test.v2)
+		assert.Equal(t, -test.result, v2.ComparePrecedence(v1), "%s vs %s", test.v2, test.v1)
+	}
+}
+
+func TestExtendedComparePrecedenceMatchesVersion(t *testing.T) {
+	for _, test := range compareTests {
+		assert.Equal(t, test.result, test.v1.Extended().ComparePrecedence(test.v2.Extended()),
+			"%s vs %s", test.v1, test.v2)
+	}
+}
+
+func TestExtendedToVersion(t *testing.T) {
+	for input, expected := range map[string]string{
+		"1":               "1.0.0",
+		"1.2-beta":        "1.2.0-beta",
+		"1.2.3+b":         "1.2.3+b",
+		"10.20.30-x+y.01": "10.20.30-x+y.01",
+	} {
+		t.Run(input, func(t *testing.T) {
+			e, err := ParseExtended(input)
+			require.NoError(t, err)
+			v, err := e.ToVersion()
+			require.NoError(t, err)
+			assert.Equal(t, expected, v.String())
+		})
+	}
+
+	for _, input := range []string{"1.2.3.4", "1.2.3.0", "1.2.3.0.0-rc.1", "10.0.19041.1-beta"} {
+		t.Run(input, func(t *testing.T) {
+			e, err := ParseExtended(input)
+			require.NoError(t, err)
+			v, err := e.ToVersion()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), input)
+			assert.Equal(t, Version{}, v)
+		})
+	}
+}
+
+func TestVersionExtended(t *testing.T) {
+	v, err := Parse("1.2.3-beta+b")
+	require.NoError(t, err)
+	e := v.Extended()
+	assert.Equal(t, "1.2.3-beta+b", e.String())
+	v2, err := e.ToVersion()
+	require.NoError(t, err)
+	assert.Equal(t, v, v2)
+}
@@ -0,0 +1,136 @@
+package semver
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// formatBufferPool provides the buffers used by Format, so that formatting a Version does not cause any
+// heap allocations once the pool is warmed up. (A buffer on the stack would escape to the heap, since it
+// has to be passed to fmt.State.Write.)
+var formatBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 64)
+		return &buf
+	},
+}
+
+// Format implements fmt.Formatter, so that a Version can be formatted in several ways by the functions in
+// the fmt package:
+//
+//   - %v writes the canonical form of the version, without its build metadata, such as "1.2.3-beta.1".
+//     Build metadata does not affect precedence, so this form identifies which versions are equivalent.
+//     This is also the form that fmt.Print, fmt.Println and text/template use.
+//   - %+v and %s write the version with its build metadata, if any, as returned by String, such as
+//     "1.2.3-beta.1+build.5".
+//   - %q writes the same thing as %s, in double quotes; %#q uses backquotes instead.
+//   - %#v writes a Go-syntax representation, such as
+//     `semver.Version{major:1, minor:2, patch:3, prerelease:"beta.1", build:"build.5"}`.
+//   - A precision writes only that many numeric components, and no prerelease or build metadata: for
+//     instance, %.2v writes "1.2" and %.1s writes "1".
+//   - A width pads the result with spaces, on the left unless the "-" flag is used.
+//
+// None of these cause heap allocations, other than whatever the fmt package does to convert the Version
+// to an interface value.
+func (v Version) Format(f fmt.State, verb rune) {
+	bufPtr := formatBufferPool.Get().(*[]byte)
+	buf := (*bufPtr)[:0]
+
+	switch verb {
+	case 'v', 's', 'q':
+		quote := byte(0)
+		if verb == 'q' {
+			quote = '"'
+			if f.Flag('#') {
+				quote = '`'
+			}
+			buf = append(buf, quote)
+		}
+		precision, hasPrecision := f.Precision()
+		switch {
+		case verb == 'v' && f.Flag('#'):
+			buf = v.appendGoSyntax(buf)
+		case hasPrecision:
+			buf = v.appendComponents(buf, precision)
+		case verb == 'v' && !f.Flag('+'):
+			buf = Version{major: v.major, minor: v.minor, patch: v.patch, prerelease: v.prerelease}.appendTo(buf)
+		default:
+			buf = v.appendTo(buf)
+		}
+		if quote != 0 {
+			buf = append(buf, quote)
+		}
+	default:
+		buf = append(buf, "%!"...)
+		buf = utf8.AppendRune(buf, verb)
+		buf = append(buf, "(semver.Version="...)
+		buf = v.appendTo(buf)
+		buf = append(buf, ')')
+	}
+
+	if width, ok := f.Width(); ok && width > len(buf) {
+		n := len(buf)
+		for len(buf) < width {
+			buf = append(buf, ' ')
+		}
+		if !f.Flag('-') {
+			copy(buf[width-n:], buf[:n])
+			for i := 0; i < width-n; i++ {
+				buf[i] = ' '
+			}
+		}
+	}
+
+	_, _ = f.Write(buf)
+	*bufPtr = buf
+	formatBufferPool.Put(bufPtr)
+}
+
+// LogValue implements slog.LogValuer, so that a Version is logged by the log/slog package as a group
+// with the attributes "major", "minor" and "patch", and also "prerelease" and "build" if the version has
+// those components. For instance, with slog.JSONHandler, the version "1.2.3-beta" is logged as
+// {"major":1,"minor":2,"patch":3,"prerelease":"beta"}.
+func (v Version) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 3, 5)
+	attrs[0] = slog.Int("major", v.major)
+	attrs[1] = slog.Int("minor", v.minor)
+	attrs[2] = slog.Int("patch", v.patch)
+	if v.prerelease != "" {
+		attrs = append(attrs, slog.String("prerelease", v.prerelease))
+	}
+	if v.build != "" {
+		attrs = append(attrs, slog.String("build", v.build))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// appendComponents appends the first n numeric components of the version, separated by periods.
+func (v Version) appendComponents(buf []byte, n int) []byte {
+	for i, component := range [3]int{v.major, v.minor, v.patch} {
+		if i >= n {
+			break
+		}
+		if i > 0 {
+			buf = append(buf, '.')
+		}
+		buf = strconv.AppendInt(buf, int64(component), 10)
+	}
+	return buf
+}
+
+func (v Version) appendGoSyntax(buf []byte) []byte {
+	buf = append(buf, "semver.Version{major:"...)
+	buf = strconv.AppendInt(buf, int64(v.major), 10)
+	buf = append(buf, ", minor:"...)
+	buf = strconv.AppendInt(buf, int64(v.minor), 10)
+	buf = append(buf, ", patch:"...)
+	buf = strconv.AppendInt(buf, int64(v.patch), 10)
+	buf = append(buf, ", prerelease:"...)
+	buf = strconv.AppendQuote(buf, v.prerelease)
+	buf = append(buf, ", build:"...)
+	buf = strconv.AppendQuote(buf, v.build)
+	return append(buf, '}')
+}
@@ -0,0 +1,24 @@
+//go:build !race
+
+package semver
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// This test is excluded from race detector builds, because the race detector makes sync.Pool drop
+// pooled buffers at random, so the buffers used by Format are sometimes allocated again.
+func TestFormatDoesNotAllocate(t *testing.T) {
+	var v any = mustParse(t, "1.2.3-beta.1+build.5") // box the value in advance, since that allocates
+	for _, format := range []string{"%v", "%+v", "%s", "%q", "%#v", "%.2v", "%20v"} {
+		fmt.Fprintf(io.Discard, format, v) // warm up the buffer pools
+		allocs := testing.AllocsPerRun(100, func() {
+			fmt.Fprintf(io.Discard, format, v)
+		})
+		assert.Equal(t, 0.0, allocs, format)
+	}
+}
@@ -0,0 +1,25 @@
+package semver
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func BenchmarkFormatSimple(b *testing.B) {
+	var v any = Version{major: 1, minor: 2, patch: 3} // box the value in advance, since that allocates
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		fmt.Fprintf(io.Discard, "%v", v)
+	}
+}
+
+func BenchmarkFormatComplex(b *testing.B) {
+	var v any = Version{major: 1, minor: 2, patch: 3, prerelease: "beta.1", build: "build.5"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		fmt.Fprintf(io.Discard, "%+v", v)
+	}
+}
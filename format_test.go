@@ -0,0 +1,89 @@
+package semver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	full := mustParse(t, "1.2.3-beta.1+build.5")
+	simple := mustParse(t, "10.0.7")
+	for _, test := range []struct {
+		format   string
+		version  Version
+		expected string
+	}{
+		{"%v", full, "1.2.3-beta.1"},
+		{"%v", simple, "10.0.7"},
+		{"%-14v|", full, "1.2.3-beta.1  |"},
+		{"%+v", full, "1.2.3-beta.1+build.5"},
+		{"%s", full, "1.2.3-beta.1+build.5"},
+		{"%q", full, `"1.2.3-beta.1+build.5"`},
+		{"%#q", full, "`1.2.3-beta.1+build.5`"},
+		{"%#v", full, `semver.Version{major:1, minor:2, patch:3, prerelease:"beta.1", build:"build.5"}`},
+		{"%#v", simple, `semver.Version{major:10, minor:0, patch:7, prerelease:"", build:""}`},
+		{"%.1v", full, "1"},
+		{"%.2v", full, "1.2"},
+		{"%.3v", full, "1.2.3"},
+		{"%.2s", simple, "10.0"},
+		{"%.2q", simple, `"10.0"`},
+		{"%.0v", full, ""},
+		{"%10v", simple, "    10.0.7"},
+		{"%-10v|", simple, "10.0.7    |"},
+		{"%3v", simple, "10.0.7"},
+		{"%8.2v", simple, "    10.0"},
+		{"%d", simple, "%!d(semver.Version=10.0.7)"},
+		{"%x", full, "%!x(semver.Version=1.2.3-beta.1+build.5)"},
+		{"%v", Version{}, "0.0.0"},
+	} {
+		t.Run(test.format+" "+test.version.String(), func(t *testing.T) {
+			assert.Equal(t, test.expected, fmt.Sprintf(test.format, test.version))
+		})
+	}
+}
+
+func TestFormatPointerAndPrint(t *testing.T) {
+	v := mustParse(t, "1.2.3-beta+build")
+	assert.Equal(t, "1.2.3-beta", fmt.Sprint(v))
+	assert.Equal(t, "1.2.3-beta", fmt.Sprintf("%v", &v))
+	assert.Equal(t, "1.2.3-beta+build", fmt.Sprintf("%+v", &v))
+	assert.Equal(t, "[1.2.3-beta 1.2.3-beta]", fmt.Sprint([]Version{v, v}))
+	assert.Equal(t, "1.2.3-beta\n", fmt.Sprintln(v))
+}
+
+func TestLogValue(t *testing.T) {
+	for _, test := range []struct {
+		version  string
+		expected string
+	}{
+		{"1.2.3", `{"major":1,"minor":2,"patch":3}`},
+		{"1.2.3-beta", `{"major":1,"minor":2,"patch":3,"prerelease":"beta"}`},
+		{"1.2.3-beta+build.1", `{"major":1,"minor":2,"patch":3,"prerelease":"beta","build":"build.1"}`},
+		{"1.2.3+build.1", `{"major":1,"minor":2,"patch":3,"build":"build.1"}`},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey || a.Key == slog.MessageKey) {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			logger.Info("", "version", mustParse(t, test.version))
+
+			var logged struct {
+				Version json.RawMessage `json:"version"`
+			}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+			assert.JSONEq(t, test.expected, string(logged.Version))
+		})
+	}
+}
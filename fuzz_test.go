@@ -0,0 +1,214 @@
+package semver
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The fuzz targets in this file check the parser and comparison logic against an independent oracle:
+// the regular expression that semver.org recommends for validating version strings, plus a simple
+// reference implementation of the precedence rules that uses arbitrary-precision integers. The package
+// itself does not use regular expressions, so these are defined only in tests.
+//
+// The seed corpus for each target is in testdata/fuzz; "go test" runs it as part of the regular tests,
+// and "go test -fuzz=FuzzParseAs" (etc.) generates new inputs.
+
+const (
+	semverNumericPattern    = `(0|[1-9]\d*)`
+	semverPrereleasePattern = `((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*)`
+	semverBuildPattern      = `([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)`
+)
+
+var (
+	// semverRegexp is the regular expression suggested in the semver.org FAQ (https://semver.org/#faq).
+	semverRegexp = regexp.MustCompile(`^` + semverNumericPattern + `\.` + semverNumericPattern + `\.` +
+		semverNumericPattern + `(?:-` + semverPrereleasePattern + `)?(?:\+` + semverBuildPattern + `)?$`)
+
+	// semverAllowMissingRegexp is the same, except that the minor and patch versions are optional, as in
+	// ParseModeAllowMissingMinorAndPatch.
+	semverAllowMissingRegexp = regexp.MustCompile(`^` + semverNumericPattern + `(?:\.` + semverNumericPattern +
+		`(?:\.` + semverNumericPattern + `)?)?(?:-` + semverPrereleasePattern + `)?(?:\+` + semverBuildPattern + `)?$`)
+
+	prereleaseRegexp        = regexp.MustCompile(`^` + semverPrereleasePattern + `$`)
+	numericIdentifierRegexp = regexp.MustCompile(`^` + semverNumericPattern + `$`)
+)
+
+func FuzzParseAs(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string, modeNumber uint8) {
+		mode := ParseMode(modeNumber % 4) // 3 is not a valid ParseMode
+		v, err := ParseAs(s, mode)
+
+		var re *regexp.Regexp
+		switch mode {
+		case ParseModeStrict:
+			re = semverRegexp
+		case ParseModeAllowMissingMinorAndPatch:
+			re = semverAllowMissingRegexp
+		case ParseModeSemVer1:
+		default:
+			require.Error(t, err, "parser accepted %q with unknown mode %d", s, mode)
+		}
+		if re != nil {
+			match := re.FindStringSubmatch(s)
+			if match == nil {
+				require.Error(t, err, "regex rejected %q but parser accepted it", s)
+				return
+			}
+			var components [3]int
+			for i := range components {
+				if match[i+1] == "" {
+					continue
+				}
+				n, atoiErr := strconv.Atoi(match[i+1])
+				if atoiErr != nil {
+					// the regex allows numbers of any size, but Version cannot represent them
+					require.Error(t, err, "parser accepted %q even though a component is too large", s)
+					return
+				}
+				components[i] = n
+			}
+			require.NoError(t, err, "regex accepted %q but parser rejected it", s)
+			assertVersionComponents(t, v, components[0], components[1], components[2], match[4], match[5])
+		}
+
+		if err != nil {
+			assert.Equal(t, Version{}, v)
+			return
+		}
+		// whatever the mode, the result must be a valid semantic version that survives a round trip
+		reparsed, err := Parse(v.String())
+		require.NoError(t, err)
+		assert.Equal(t, v, reparsed)
+	})
+}
+
+func FuzzComparePrecedence(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s1, s2, s3 string) {
+		v1, err1 := Parse(s1)
+		v2, err2 := Parse(s2)
+		v3, err3 := Parse(s3)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return
+		}
+
+		assert.Equal(t, 0, v1.ComparePrecedence(v1), "reflexivity")
+		d12, d21 := v1.ComparePrecedence(v2), v2.ComparePrecedence(v1)
+		assert.Equal(t, -d12, d21, "antisymmetry")
+		assert.Equal(t, referenceComparePrecedence(s1, s2), d12, "%q vs %q", s1, s2)
+
+		d23, d13 := v2.ComparePrecedence(v3), v1.ComparePrecedence(v3)
+		if d12 <= 0 && d23 <= 0 {
+			assert.LessOrEqual(t, d13, 0, "transitivity")
+			if d12 == 0 && d23 == 0 {
+				assert.Equal(t, 0, d13, "transitivity of equality")
+			}
+		}
+		if d12 >= 0 && d23 >= 0 {
+			assert.GreaterOrEqual(t, d13, 0, "transitivity")
+		}
+	})
+}
+
+func FuzzComparePrereleaseIdentifiers(f *testing.F) {
+	f.Fuzz(func(t *testing.T, p1, p2 string) {
+		if !prereleaseRegexp.MatchString(p1) || !prereleaseRegexp.MatchString(p2) {
+			return
+		}
+		d := comparePrereleaseIdentifiers(p1, p2)
+		assert.Equal(t, referenceComparePrereleases(p1, p2), d, "%q vs %q", p1, p2)
+		assert.Equal(t, -d, comparePrereleaseIdentifiers(p2, p1), "antisymmetry")
+		assert.Equal(t, 0, comparePrereleaseIdentifiers(p1, p1), "reflexivity")
+	})
+}
+
+// referenceComparePrecedence implements the precedence rules of the spec directly, for two strings that
+// are already known to be valid versions.
+func referenceComparePrecedence(s1, s2 string) int {
+	m1, m2 := semverRegexp.FindStringSubmatch(s1), semverRegexp.FindStringSubmatch(s2)
+	for i := 1; i <= 3; i++ {
+		if d := referenceCompareNumbers(m1[i], m2[i]); d != 0 {
+			return d
+		}
+	}
+	switch {
+	case m1[4] == "" && m2[4] == "":
+		return 0
+	case m1[4] == "":
+		return 1
+	case m2[4] == "":
+		return -1
+	default:
+		return referenceComparePrereleases(m1[4], m2[4])
+	}
+}
+
+func referenceComparePrereleases(p1, p2 string) int {
+	ids1, ids2 := strings.Split(p1, "."), strings.Split(p2, ".")
+	for i := 0; i < len(ids1) && i < len(ids2); i++ {
+		numeric1, numeric2 := numericIdentifierRegexp.MatchString(ids1[i]), numericIdentifierRegexp.MatchString(ids2[i])
+		var d int
+		switch {
+		case numeric1 && numeric2:
+			d = referenceCompareNumbers(ids1[i], ids2[i])
+		case numeric1:
+			d = -1
+		case numeric2:
+			d = 1
+		default:
+			d = strings.Compare(ids1[i], ids2[i])
+		}
+		if d != 0 {
+			return d
+		}
+	}
+	switch {
+	case len(ids1) < len(ids2):
+		return -1
+	case len(ids1) > len(ids2):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func referenceCompareNumbers(s1, s2 string) int {
+	n1, _ := new(big.Int).SetString(s1, 10)
+	n2, _ := new(big.Int).SetString(s2, 10)
+	return n1.Cmp(n2)
+}
+
+func TestParsingRejectsComponentsThatOverflow(t *testing.T) {
+	for _, s := range []string{
+		"9223372036854775808.0.0",
+		"0.9223372036854775808.0",
+		"0.0.9223372036854775808",
+		"99999999999999999999.0.0",
+		"18446744073709551617.0.0", // would wrap around to 1 with 64-bit ints
+	} {
+		t.Run(s, parsingShouldFail(Parse, s))
+	}
+	t.Run("largest int is allowed", parsingShouldSucceed(Parse, strconv.Itoa(int(^uint(0)>>1))+".0.0",
+		int(^uint(0)>>1), 0, 0, "", ""))
+}
+
+func TestComparingLargeNumericPrereleaseIdentifiers(t *testing.T) {
+	v1, err := Parse("1.0.0-18446744073709551617")
+	require.NoError(t, err)
+	v2, err := Parse("1.0.0-2")
+	require.NoError(t, err)
+	v3, err := Parse("1.0.0-alpha")
+	require.NoError(t, err)
+	assert.Equal(t, 1, v1.ComparePrecedence(v2))
+	assert.Equal(t, -1, v1.ComparePrecedence(v3), "numeric identifiers of any size are lower than alphanumeric ones")
+
+	r, err := ParseRange(">=1.0.0-2 <1.0.0-99999999999999999999")
+	require.NoError(t, err)
+	assert.True(t, r.Contains(v1))
+	assert.True(t, r.Compile().Matches(v1))
+}
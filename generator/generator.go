@@ -0,0 +1,169 @@
+// Package generator produces random semantic versions and version strings for property-based tests,
+// including "almost valid" strings that have been deliberately mutated so that they must be rejected.
+//
+// The Version, ValidString and InvalidString types implement quick.Generator, so they can be used
+// directly as parameters of functions passed to testing/quick.Check:
+//
+//	quick.Check(func(v generator.Version) bool {
+//		parsed, err := semver.Parse(v.String())
+//		return err == nil && parsed == v.Version
+//	}, nil)
+//
+// For other distributions, create a Config and call its Version, ValidString or InvalidString methods
+// with a *rand.Rand.
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// IntRange is an inclusive range of non-negative integers, from which a Config chooses values.
+type IntRange struct {
+	Min int
+	Max int
+}
+
+// Config describes the distribution of the versions that are generated. The zero value of a field does
+// not mean "use the default"; start from DefaultConfig to change only some of them.
+type Config struct {
+	// Major, Minor and Patch are the ranges for the numeric components of the version.
+	Major, Minor, Patch IntRange
+
+	// EdgeProbability is the probability that a numeric component, or a numeric prerelease identifier,
+	// is the minimum or maximum of its range instead of a value chosen uniformly from the range.
+	EdgeProbability float64
+
+	// PrereleaseProbability is the probability that the version has a prerelease.
+	PrereleaseProbability float64
+
+	// PrereleaseIdentifiers is the range for the number of dot-separated identifiers in a prerelease.
+	PrereleaseIdentifiers IntRange
+
+	// NumericIdentifierProbability is the probability that a prerelease identifier is numeric, rather
+	// than alphanumeric.
+	NumericIdentifierProbability float64
+
+	// NumericIdentifier is the range for the values of numeric prerelease identifiers.
+	NumericIdentifier IntRange
+
+	// BuildProbability is the probability that the version has build metadata.
+	BuildProbability float64
+
+	// BuildIdentifiers is the range for the number of dot-separated identifiers in build metadata.
+	BuildIdentifiers IntRange
+
+	// IdentifierLength is the range for the length of alphanumeric prerelease identifiers and of build
+	// identifiers. Its minimum is treated as 1 if it is lower.
+	IdentifierLength IntRange
+
+	// Mutations are the kinds of mutation that InvalidString may apply. If it is empty, all of them may
+	// be applied.
+	Mutations []Mutation
+}
+
+// DefaultConfig is the Config used by the quick.Generator implementations in this package. It mostly
+// produces small numbers and short identifiers, so that generated versions often share components, with
+// occasional very large values.
+var DefaultConfig = Config{
+	Major:                        IntRange{0, 1000},
+	Minor:                        IntRange{0, 100},
+	Patch:                        IntRange{0, 100},
+	EdgeProbability:              0.1,
+	PrereleaseProbability:        0.5,
+	PrereleaseIdentifiers:        IntRange{1, 4},
+	NumericIdentifierProbability: 0.4,
+	NumericIdentifier:            IntRange{0, 20},
+	BuildProbability:             0.3,
+	BuildIdentifiers:             IntRange{1, 3},
+	IdentifierLength:             IntRange{1, 8},
+}
+
+const (
+	identifierChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
+	nonDigitChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
+)
+
+// Version returns a random valid version.
+func (c Config) Version(r *rand.Rand) semver.Version {
+	v, err := semver.Parse(c.ValidString(r))
+	if err != nil {
+		panic(err) // COVERAGE: can only happen if this package has a bug
+	}
+	return v
+}
+
+// ValidString returns a random valid version string.
+func (c Config) ValidString(r *rand.Rand) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d.%d.%d", c.number(r, c.Major), c.number(r, c.Minor), c.number(r, c.Patch))
+	if r.Float64() < c.PrereleaseProbability {
+		sb.WriteByte('-')
+		sb.WriteString(c.prerelease(r))
+	}
+	if r.Float64() < c.BuildProbability {
+		sb.WriteByte('+')
+		sb.WriteString(c.build(r))
+	}
+	return sb.String()
+}
+
+func (c Config) prerelease(r *rand.Rand) string {
+	ids := make([]string, max(1, c.intIn(r, c.PrereleaseIdentifiers)))
+	for i := range ids {
+		if r.Float64() < c.NumericIdentifierProbability {
+			ids[i] = fmt.Sprint(c.number(r, c.NumericIdentifier))
+		} else {
+			ids[i] = c.alphanumericIdentifier(r)
+		}
+	}
+	return strings.Join(ids, ".")
+}
+
+func (c Config) build(r *rand.Rand) string {
+	ids := make([]string, max(1, c.intIn(r, c.BuildIdentifiers)))
+	for i := range ids {
+		ids[i] = c.identifier(r, identifierChars)
+	}
+	return strings.Join(ids, ".")
+}
+
+// alphanumericIdentifier returns an identifier that has at least one non-digit, so that it is not
+// subject to the rule against leading zeroes.
+func (c Config) alphanumericIdentifier(r *rand.Rand) string {
+	id := []byte(c.identifier(r, identifierChars))
+	if strings.IndexAny(string(id), nonDigitChars) < 0 {
+		id[r.Intn(len(id))] = nonDigitChars[r.Intn(len(nonDigitChars))]
+	}
+	return string(id)
+}
+
+func (c Config) identifier(r *rand.Rand, chars string) string {
+	id := make([]byte, max(1, c.intIn(r, c.IdentifierLength)))
+	for i := range id {
+		id[i] = chars[r.Intn(len(chars))]
+	}
+	return string(id)
+}
+
+// number chooses a value from the range, favoring its endpoints with probability EdgeProbability.
+func (c Config) number(r *rand.Rand, rng IntRange) int {
+	if r.Float64() < c.EdgeProbability {
+		if r.Intn(2) == 0 {
+			return max(0, rng.Min)
+		}
+		return max(0, rng.Max)
+	}
+	return c.intIn(r, rng)
+}
+
+func (c Config) intIn(r *rand.Rand, rng IntRange) int {
+	lo, hi := max(0, rng.Min), max(0, rng.Max)
+	if hi <= lo {
+		return lo
+	}
+	return lo + int(r.Uint64()%(uint64(hi-lo)+1))
+}
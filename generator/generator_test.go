@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickGenerators(t *testing.T) {
+	config := &quick.Config{MaxCount: 2000}
+
+	assert.NoError(t, quick.Check(func(v Version) bool {
+		parsed, err := semver.Parse(v.String())
+		return err == nil && parsed == v.Version
+	}, config))
+
+	assert.NoError(t, quick.Check(func(s ValidString) bool {
+		_, err := semver.Parse(string(s))
+		return err == nil
+	}, config))
+
+	assert.NoError(t, quick.Check(func(s InvalidString) bool {
+		_, err := semver.Parse(string(s))
+		return err != nil
+	}, config))
+}
+
+func TestEveryMutationProducesInvalidStrings(t *testing.T) {
+	for _, m := range Mutations {
+		t.Run(m.String(), func(t *testing.T) {
+			c := DefaultConfig
+			c.Mutations = []Mutation{m}
+			r := rand.New(rand.NewSource(int64(m)))
+			for i := 0; i < 2000; i++ {
+				s := c.InvalidString(r)
+				_, err := semver.Parse(s)
+				require.Error(t, err, "mutation %s produced valid string %q", m, s)
+			}
+		})
+	}
+}
+
+func TestMutationString(t *testing.T) {
+	assert.Equal(t, "LeadingZero", LeadingZero.String())
+	assert.Equal(t, "Overflow", Overflow.String())
+	assert.Equal(t, "Mutation(?)", Mutation(99).String())
+}
+
+func TestSameSeedProducesSameValues(t *testing.T) {
+	r1, r2 := rand.New(rand.NewSource(1)), rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, DefaultConfig.ValidString(r1), DefaultConfig.ValidString(r2))
+		assert.Equal(t, DefaultConfig.InvalidString(r1), DefaultConfig.InvalidString(r2))
+	}
+}
+
+func TestConfigDistributions(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+
+	t.Run("no prerelease or build", func(t *testing.T) {
+		c := DefaultConfig
+		c.PrereleaseProbability, c.BuildProbability = 0, 0
+		for i := 0; i < 500; i++ {
+			v := c.Version(r)
+			assert.Equal(t, "", v.GetPrerelease())
+			assert.Equal(t, "", v.GetBuild())
+		}
+	})
+
+	t.Run("component ranges and edges", func(t *testing.T) {
+		c := DefaultConfig
+		c.Major, c.Minor, c.Patch = IntRange{5, 7}, IntRange{0, 0}, IntRange{100, 200}
+		c.EdgeProbability = 1
+		for i := 0; i < 500; i++ {
+			v := c.Version(r)
+			assert.Contains(t, []int{5, 7}, v.GetMajor())
+			assert.Equal(t, 0, v.GetMinor())
+			assert.Contains(t, []int{100, 200}, v.GetPatch())
+		}
+	})
+
+	t.Run("numeric prerelease identifiers", func(t *testing.T) {
+		c := DefaultConfig
+		c.PrereleaseProbability, c.NumericIdentifierProbability = 1, 1
+		c.PrereleaseIdentifiers, c.NumericIdentifier = IntRange{3, 3}, IntRange{0, 9}
+		for i := 0; i < 500; i++ {
+			ids := strings.Split(c.Version(r).GetPrerelease(), ".")
+			require.Len(t, ids, 3)
+			for _, id := range ids {
+				assert.Len(t, id, 1)
+				assert.True(t, id[0] >= '0' && id[0] <= '9')
+			}
+		}
+	})
+
+	t.Run("alphanumeric prerelease identifiers", func(t *testing.T) {
+		c := DefaultConfig
+		c.PrereleaseProbability, c.NumericIdentifierProbability = 1, 0
+		c.IdentifierLength = IntRange{1, 2}
+		for i := 0; i < 500; i++ {
+			for _, id := range strings.Split(c.Version(r).GetPrerelease(), ".") {
+				assert.True(t, len(id) >= 1 && len(id) <= 2)
+				assert.True(t, strings.IndexAny(id, nonDigitChars) >= 0, "identifier %q should not be numeric", id)
+			}
+		}
+	})
+
+	t.Run("huge ranges", func(t *testing.T) {
+		c := DefaultConfig
+		c.Major = IntRange{0, int(^uint(0) >> 1)}
+		for i := 0; i < 500; i++ {
+			assert.GreaterOrEqual(t, c.Version(r).GetMajor(), 0)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Mutation is a kind of change that InvalidString makes to a valid version string, so that the result
+// is "almost valid" but must be rejected by a strict parser.
+type Mutation int
+
+const (
+	// LeadingZero adds a leading zero to the major, minor or patch version, as in "01.2.3".
+	LeadingZero Mutation = iota
+	// LeadingZeroInPrerelease adds a numeric prerelease identifier with a leading zero, as in "1.2.3-01".
+	LeadingZeroInPrerelease
+	// MissingComponent removes the patch version, or the minor and patch versions, as in "1.2".
+	MissingComponent
+	// ExtraComponent adds a fourth numeric component, as in "1.2.3.4".
+	ExtraComponent
+	// EmptyIdentifier adds an empty prerelease or build identifier, as in "1.2.3-a..b" or "1.2.3+".
+	EmptyIdentifier
+	// InvalidCharacter inserts a character that is not allowed anywhere in a version, as in "1.2.3-a_b".
+	InvalidCharacter
+	// Prefix adds text before the version, such as "v" or whitespace, as in "v1.2.3".
+	Prefix
+	// Suffix adds text after the version, such as whitespace or a second build section, as in "1.2.3 ".
+	Suffix
+	// Overflow replaces a numeric component with a number that is too large to represent, as in
+	// "99999999999999999999.2.3".
+	Overflow
+)
+
+// Mutations lists every kind of Mutation.
+var Mutations = []Mutation{
+	LeadingZero, LeadingZeroInPrerelease, MissingComponent, ExtraComponent, EmptyIdentifier,
+	InvalidCharacter, Prefix, Suffix, Overflow,
+}
+
+// String returns the name of the mutation.
+func (m Mutation) String() string {
+	switch m {
+	case LeadingZero:
+		return "LeadingZero"
+	case LeadingZeroInPrerelease:
+		return "LeadingZeroInPrerelease"
+	case MissingComponent:
+		return "MissingComponent"
+	case ExtraComponent:
+		return "ExtraComponent"
+	case EmptyIdentifier:
+		return "EmptyIdentifier"
+	case InvalidCharacter:
+		return "InvalidCharacter"
+	case Prefix:
+		return "Prefix"
+	case Suffix:
+		return "Suffix"
+	case Overflow:
+		return "Overflow"
+	default:
+		return "Mutation(?)"
+	}
+}
+
+// InvalidString returns a random invalid version string, made by applying one of the configured
+// mutations to a random valid version string.
+func (c Config) InvalidString(r *rand.Rand) string {
+	mutations := c.Mutations
+	if len(mutations) == 0 {
+		mutations = Mutations
+	}
+	return c.Mutate(r, c.ValidString(r), mutations[r.Intn(len(mutations))])
+}
+
+// Mutate applies a mutation to a valid version string, such as one returned by ValidString, so that it
+// becomes invalid. Its behavior is undefined if s is not a valid version string.
+func (c Config) Mutate(r *rand.Rand, s string, m Mutation) string {
+	version, suffix := s, ""
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		version, suffix = s[:i], s[i:]
+	}
+	components := strings.Split(version, ".")
+
+	switch m {
+	case LeadingZero:
+		i := r.Intn(3)
+		components[i] = "0" + components[i]
+	case LeadingZeroInPrerelease:
+		id := "0" + string(rune('0'+r.Intn(10)))
+		if strings.HasPrefix(suffix, "-") {
+			return version + suffix[:1] + id + "." + suffix[1:]
+		}
+		return version + "-" + id + suffix
+	case MissingComponent:
+		components = components[:1+r.Intn(2)]
+	case ExtraComponent:
+		components = append(components, components[r.Intn(3)])
+	case EmptyIdentifier:
+		switch r.Intn(4) {
+		case 0:
+			return version + "-." + strings.TrimPrefix(suffix, "-")
+		case 1:
+			return s + "+"
+		case 2:
+			return version + "-a.." + strings.TrimPrefix(suffix, "-")
+		default:
+			return s + "." // the last identifier of the prerelease or build, or of the version, becomes empty
+		}
+	case InvalidCharacter:
+		chars := "_ !~/:é\x00"
+		ch := string([]rune(chars)[r.Intn(len([]rune(chars)))])
+		pos := 1 + r.Intn(len(s)-1) // never before the first character, so it can't be confused with a prefix
+		return s[:pos] + ch + s[pos:]
+	case Prefix:
+		prefixes := []string{"v", "V", "=", " ", "\t", "-", "+", "."}
+		return prefixes[r.Intn(len(prefixes))] + s
+	case Suffix:
+		suffixes := []string{" ", "\n", ".", "+a+b", "_"}
+		return s + suffixes[r.Intn(len(suffixes))]
+	case Overflow:
+		components[r.Intn(3)] = "9" + strings.Repeat("9", 19+r.Intn(10))
+	}
+	return strings.Join(components, ".") + suffix
+}
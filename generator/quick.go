@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"math/rand"
+	"reflect"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// Version is a semver.Version that implements quick.Generator, by generating random versions with
+// DefaultConfig.
+type Version struct {
+	semver.Version
+}
+
+// ValidString is a valid version string that implements quick.Generator, by generating random strings
+// with DefaultConfig.
+type ValidString string
+
+// InvalidString is an invalid version string that implements quick.Generator, by generating random
+// mutated strings with DefaultConfig.
+type InvalidString string
+
+// Generate implements quick.Generator. The size parameter is ignored.
+func (Version) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(Version{DefaultConfig.Version(r)})
+}
+
+// Generate implements quick.Generator. The size parameter is ignored.
+func (ValidString) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(ValidString(DefaultConfig.ValidString(r)))
+}
+
+// Generate implements quick.Generator. The size parameter is ignored.
+func (InvalidString) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(InvalidString(DefaultConfig.InvalidString(r)))
+}
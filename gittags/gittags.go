@@ -0,0 +1,102 @@
+// Package gittags finds semantic version tags in a local git repository and computes the next version
+// to release.
+//
+// Tags are read directly from the repository's refs and packed-refs files, so the git executable is not
+// required. A tag is treated as a version if its name, after removing an optional "v" prefix, is a valid
+// semantic version according to semver.ParseModeStrict; any other tags are ignored.
+package gittags
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// Tag is a git tag whose name is a semantic version.
+type Tag struct {
+	// Name is the tag name without the "refs/tags/" prefix, such as "v1.2.3".
+	Name string
+
+	// Version is the semantic version parsed from Name.
+	Version semver.Version
+
+	// Object is the hex object ID that the tag ref points to. For an annotated tag, this is the ID of
+	// the tag object rather than of the commit.
+	Object string
+}
+
+// ParseTagName attempts to parse a tag name as a semantic version, allowing an optional "v" or "V"
+// prefix as in "v1.2.3". The second return value is false if the name is not a valid version.
+func ParseTagName(name string) (semver.Version, bool) {
+	if strings.HasPrefix(name, "v") || strings.HasPrefix(name, "V") {
+		name = name[1:]
+	}
+	v, err := semver.Parse(name)
+	return v, err == nil
+}
+
+// List returns all of the version tags in the git repository at repoDir, sorted in ascending order of
+// precedence. If several tags have the same precedence (for instance "1.0.0" and "v1.0.0", or versions
+// that differ only in build metadata), they are sorted by name.
+//
+// The repoDir parameter may be the root of a working tree, a linked worktree, or a bare repository.
+func List(repoDir string) ([]Tag, error) {
+	refs, err := readTagRefs(repoDir)
+	if err != nil {
+		return nil, err
+	}
+	var tags []Tag
+	for name, object := range refs {
+		if v, ok := ParseTagName(name); ok {
+			tags = append(tags, Tag{Name: name, Version: v, Object: object})
+		}
+	}
+	SortTags(tags)
+	return tags, nil
+}
+
+// SortTags sorts tags in ascending order of precedence, using the tag name to break ties.
+func SortTags(tags []Tag) {
+	sort.Slice(tags, func(i, j int) bool {
+		if d := tags[i].Version.ComparePrecedence(tags[j].Version); d != 0 {
+			return d < 0
+		}
+		return tags[i].Name < tags[j].Name
+	})
+}
+
+// Latest returns the version tag with the highest precedence in the repository at repoDir. If
+// includePrereleases is false, tags with a prerelease component are skipped. The second return value
+// is false if there is no matching tag.
+func Latest(repoDir string, includePrereleases bool) (Tag, bool, error) {
+	tags, err := List(repoDir)
+	if err != nil {
+		return Tag{}, false, err
+	}
+	tag, ok := latestOf(tags, includePrereleases)
+	return tag, ok, nil
+}
+
+// Next returns the version that should be released after the latest version tag in the repository at
+// repoDir, for the specified kind of change. Prerelease tags are taken into account, so that a minor
+// bump after "v1.3.0-rc.1" produces 1.3.0; see semver.Version.Bump for the exact rules.
+//
+// If the repository has no version tags, the result is the specified bump applied to 0.0.0.
+func Next(repoDir string, kind semver.BumpKind) (semver.Version, error) {
+	tags, err := List(repoDir)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	latest, _ := latestOf(tags, true)
+	return latest.Version.Bump(kind), nil
+}
+
+func latestOf(sortedTags []Tag, includePrereleases bool) (Tag, bool) {
+	for i := len(sortedTags) - 1; i >= 0; i-- {
+		if includePrereleases || sortedTags[i].Version.GetPrerelease() == "" {
+			return sortedTags[i], true
+		}
+	}
+	return Tag{}, false
+}
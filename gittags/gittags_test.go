@@ -0,0 +1,262 @@
+package gittags
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git executable not available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "first")
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com",
+		"-c", "tag.gpgSign=false", "-c", "commit.gpgSign=false"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL="+os.DevNull, "GIT_CONFIG_NOSYSTEM=1")
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %s: %s", strings.Join(args, " "), out)
+	return strings.TrimSpace(string(out))
+}
+
+func tagNames(tags []Tag) []string {
+	var ret []string
+	for _, t := range tags {
+		ret = append(ret, t.Name)
+	}
+	return ret
+}
+
+func TestParseTagName(t *testing.T) {
+	for _, name := range []string{"1.2.3", "v1.2.3", "V1.2.3"} {
+		v, ok := ParseTagName(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, "1.2.3", v.String())
+	}
+	for _, name := range []string{"", "v", "vv1.2.3", "1.2", "v1.2", "release-1.2.3", "latest", "1.2.3.4"} {
+		_, ok := ParseTagName(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := makeTestRepo(t)
+	for _, tag := range []string{"v1.10.0", "v1.2.0", "1.2.0", "v1.2.0-rc.1", "v1.9.9", "latest", "release/2.0.0"} {
+		runGit(t, dir, "tag", tag)
+	}
+	runGit(t, dir, "tag", "-a", "-m", "annotated", "v2.0.0-beta.2")
+
+	tags, err := List(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.2.0-rc.1", "1.2.0", "v1.2.0", "v1.9.9", "v1.10.0", "v2.0.0-beta.2"}, tagNames(tags))
+
+	head := runGit(t, dir, "rev-parse", "HEAD")
+	assert.Equal(t, head, tags[0].Object)
+	assert.Equal(t, runGit(t, dir, "rev-parse", "v2.0.0-beta.2"), tags[5].Object)
+	assert.NotEqual(t, head, tags[5].Object)
+}
+
+func TestListPackedRefs(t *testing.T) {
+	dir := makeTestRepo(t)
+	first := runGit(t, dir, "rev-parse", "HEAD")
+	runGit(t, dir, "tag", "v1.0.0")
+	runGit(t, dir, "tag", "-a", "-m", "annotated", "v1.1.0")
+	runGit(t, dir, "tag", "v1.2.0")
+	runGit(t, dir, "pack-refs", "--all")
+	require.NoFileExists(t, filepath.Join(dir, ".git", "refs", "tags", "v1.0.0"))
+
+	runGit(t, dir, "commit", "-q", "--allow-empty", "-m", "second")
+	second := runGit(t, dir, "rev-parse", "HEAD")
+	runGit(t, dir, "tag", "v1.3.0")
+	runGit(t, dir, "tag", "-f", "v1.2.0") // loose ref now overrides the packed one
+
+	tags, err := List(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v1.1.0", "v1.2.0", "v1.3.0"}, tagNames(tags))
+	assert.Equal(t, first, tags[0].Object)
+	assert.Equal(t, runGit(t, dir, "rev-parse", "v1.1.0"), tags[1].Object)
+	assert.Equal(t, second, tags[2].Object)
+	assert.Equal(t, second, tags[3].Object)
+}
+
+func TestListWorktreeAndBareRepository(t *testing.T) {
+	dir := makeTestRepo(t)
+	runGit(t, dir, "tag", "v1.0.0")
+	runGit(t, dir, "tag", "v1.1.0")
+
+	worktree := filepath.Join(t.TempDir(), "wt")
+	runGit(t, dir, "worktree", "add", "-q", worktree)
+	tags, err := List(worktree)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v1.1.0"}, tagNames(tags))
+
+	bare := filepath.Join(t.TempDir(), "bare.git")
+	runGit(t, dir, "clone", "-q", "--bare", dir, bare)
+	tags, err = List(bare)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0", "v1.1.0"}, tagNames(tags))
+}
+
+func TestListNoTags(t *testing.T) {
+	dir := makeTestRepo(t)
+	tags, err := List(dir)
+	require.NoError(t, err)
+	assert.Len(t, tags, 0)
+}
+
+func TestListNotARepository(t *testing.T) {
+	_, err := List(t.TempDir())
+	assert.True(t, errors.Is(err, errNotGitRepository))
+}
+
+// writeFiles creates files under dir, with paths relative to dir in slash-separated form.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for path, content := range files {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// makeSymlinkLoop creates a symbolic link that points to itself, so that opening it fails.
+func makeSymlinkLoop(t *testing.T, path string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	if err := os.Symlink(filepath.Base(path), path); err != nil {
+		t.Skipf("can't create symbolic link: %s", err)
+	}
+}
+
+func TestListWithoutGitExecutable(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".git/HEAD":              "ref: refs/heads/main\n",
+		".git/packed-refs":       "# pack-refs with: peeled\n\nabc refs/tags/v1.0.0\n^def\nabc refs/heads/main\n",
+		".git/refs/tags/v1.1.0":  "123\n",
+		".git/refs/tags/symbol":  "ref: refs/tags/v1.1.0\n",
+		".git/refs/tags/v1.2.0":  "",
+		".git/refs/tags/a/2.0.0": "456\n",
+	})
+	tags, err := List(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0", "v1.1.0"}, tagNames(tags))
+	assert.Equal(t, "abc", tags[0].Object)
+	assert.Equal(t, "123", tags[1].Object)
+
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, ".git", "refs")))
+	tags, err = List(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, tagNames(tags))
+}
+
+func TestListMalformedRepository(t *testing.T) {
+	t.Run(".git file without gitdir", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{".git": "something else\n"})
+		_, err := List(dir)
+		assert.True(t, errors.Is(err, errNotGitRepository))
+	})
+
+	t.Run("unreadable .git", func(t *testing.T) {
+		dir := t.TempDir()
+		makeSymlinkLoop(t, filepath.Join(dir, ".git"))
+		_, err := List(dir)
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, errNotGitRepository))
+	})
+
+	t.Run("unreadable commondir", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git", "commondir"), 0o755))
+		_, err := List(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("commondir is not a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{".git/commondir": "../file", "file": ""})
+		_, err := List(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("unreadable packed-refs", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git", "packed-refs"), 0o755))
+		_, err := List(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("unreadable loose ref", func(t *testing.T) {
+		dir := t.TempDir()
+		makeSymlinkLoop(t, filepath.Join(dir, ".git", "refs", "tags", "v1.0.0"))
+		_, err := List(dir)
+		assert.Error(t, err)
+	})
+}
+
+func TestLatest(t *testing.T) {
+	dir := makeTestRepo(t)
+
+	_, ok, err := Latest(dir, true)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	runGit(t, dir, "tag", "v1.0.0")
+	runGit(t, dir, "tag", "v1.1.0-rc.1")
+
+	tag, ok, err := Latest(dir, true)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.1.0-rc.1", tag.Name)
+
+	tag, ok, err = Latest(dir, false)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0", tag.Name)
+
+	_, _, err = Latest(t.TempDir(), true)
+	assert.Error(t, err)
+}
+
+func TestNext(t *testing.T) {
+	dir := makeTestRepo(t)
+
+	next, err := Next(dir, semver.BumpMinor)
+	require.NoError(t, err)
+	assert.Equal(t, "0.1.0", next.String())
+
+	runGit(t, dir, "tag", "v1.2.3")
+	for kind, expected := range map[semver.BumpKind]string{
+		semver.BumpNone:  "1.2.3",
+		semver.BumpPatch: "1.2.4",
+		semver.BumpMinor: "1.3.0",
+		semver.BumpMajor: "2.0.0",
+	} {
+		next, err := Next(dir, kind)
+		require.NoError(t, err)
+		assert.Equal(t, expected, next.String(), kind.String())
+	}
+
+	runGit(t, dir, "tag", "v1.3.0-rc.1")
+	next, err = Next(dir, semver.BumpMinor)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.0", next.String())
+
+	_, err = Next(t.TempDir(), semver.BumpMinor)
+	assert.Error(t, err)
+}
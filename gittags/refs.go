@@ -0,0 +1,146 @@
+package gittags
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const tagRefPrefix = "refs/tags/"
+
+var errNotGitRepository = errors.New("not a git repository")
+
+// readTagRefs returns a map of tag names (without "refs/tags/") to object IDs. Loose refs take priority
+// over packed refs, which is how git itself resolves them.
+func readTagRefs(repoDir string) (map[string]string, error) {
+	gitDir, err := findGitDir(repoDir)
+	if err != nil {
+		return nil, err
+	}
+	refsDir, err := findCommonDir(gitDir)
+	if err != nil {
+		return nil, err
+	}
+	refs := make(map[string]string)
+	if err := readPackedRefs(filepath.Join(refsDir, "packed-refs"), refs); err != nil {
+		return nil, err
+	}
+	if err := readLooseRefs(filepath.Join(refsDir, filepath.FromSlash(tagRefPrefix)), refs); err != nil {
+		return nil, err
+	}
+	return refs, nil
+}
+
+// findGitDir returns the git directory for repoDir, which may be a working tree whose .git entry is a
+// directory, a linked worktree or submodule whose .git entry is a "gitdir:" file, or a bare repository.
+func findGitDir(repoDir string) (string, error) {
+	dotGit := filepath.Join(repoDir, ".git")
+	info, err := os.Stat(dotGit)
+	switch {
+	case err == nil && info.IsDir():
+		return dotGit, nil
+	case err == nil:
+		data, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", err // COVERAGE: can only happen if .git is unreadable, which tests can't set up portably
+		}
+		line := strings.TrimSpace(string(data))
+		if !strings.HasPrefix(line, "gitdir:") {
+			return "", fmt.Errorf("%s: %w", dotGit, errNotGitRepository)
+		}
+		return resolvePath(repoDir, strings.TrimSpace(strings.TrimPrefix(line, "gitdir:"))), nil
+	case errors.Is(err, fs.ErrNotExist):
+		if isFile(filepath.Join(repoDir, "HEAD")) && isDir(filepath.Join(repoDir, "refs")) {
+			return repoDir, nil // bare repository
+		}
+		return "", fmt.Errorf("%s: %w", repoDir, errNotGitRepository)
+	default:
+		return "", err
+	}
+}
+
+// findCommonDir returns the directory containing the shared refs for gitDir; for a linked worktree this
+// is named by its "commondir" file, and otherwise it is gitDir itself.
+func findCommonDir(gitDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return gitDir, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return resolvePath(gitDir, strings.TrimSpace(string(data))), nil
+}
+
+func readPackedRefs(path string, refs map[string]string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Each line is either a "# pack-refs with: ..." header, a "^<object>" line giving the peeled value of
+	// the preceding annotated tag, or "<object> <refname>".
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		object, refName, ok := strings.Cut(line, " ")
+		if ok && strings.HasPrefix(refName, tagRefPrefix) {
+			refs[strings.TrimPrefix(refName, tagRefPrefix)] = object
+		}
+	}
+	return scanner.Err()
+}
+
+func readLooseRefs(tagsDir string, refs map[string]string) error {
+	err := filepath.WalkDir(tagsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		object := strings.TrimSpace(string(data))
+		if object == "" || strings.HasPrefix(object, "ref:") {
+			return nil // symbolic refs are not meaningful for tags
+		}
+		name, err := filepath.Rel(tagsDir, path)
+		if err != nil {
+			return err // COVERAGE: can't happen, since WalkDir only visits paths within tagsDir
+		}
+		refs[filepath.ToSlash(name)] = object
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func resolvePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(base, path)
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
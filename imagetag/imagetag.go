@@ -0,0 +1,262 @@
+// Package imagetag interprets the tags of Docker/OCI container images as versions, so that the best tag
+// can be selected from a registry's tag list.
+//
+// Image tags loosely follow semantic versioning, with two differences. First, a tag may have a variant
+// suffix that describes how the image was built rather than which version it contains, as in
+// "1.25.3-alpine" or "1.25.3-bookworm"; tags with different variants are not interchangeable. Second, a
+// tag with fewer than three numeric components, such as "1.25" or "1", is usually a floating tag that is
+// moved to the newest matching release, so "1.25" means the highest "1.25.x" rather than "1.25.0".
+package imagetag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// Tag is an image tag that has been interpreted as a version.
+type Tag struct {
+	// Name is the original tag, such as "1.25.3-alpine".
+	Name string
+
+	// Version is the version that the tag refers to, such as 1.25.3. Missing components are zero, so the
+	// Version of the floating tag "1.25" is 1.25.0; see Resolve. A prerelease such as "rc.1" in
+	// "1.26.0-rc.1-alpine" is part of the Version.
+	Version semver.Version
+
+	// Components is the number of numeric components in the tag, from 1 to 3.
+	Components int
+
+	// Variant is the variant suffix, such as "alpine" in "1.25.3-alpine", or "" if there is none.
+	Variant string
+}
+
+// prereleaseKinds are the words that mark the first part of a tag suffix as a prerelease rather than a
+// variant.
+var prereleaseKinds = []string{"alpha", "beta", "rc", "pre", "preview", "dev"}
+
+// ParseTag attempts to interpret an image tag as a version. It returns false if the tag is not a version,
+// such as "latest" or "alpine".
+//
+// A version tag consists of an optional "v", one to three numeric components separated by periods, and
+// optionally a hyphen and a suffix. Numeric components may have leading zeroes, as in "20.04". If the
+// first hyphen-separated part of the suffix is a prerelease, that is, one of "alpha", "beta", "rc", "pre",
+// "preview" or "dev", optionally followed by a number (as in "rc1" or "rc.1"), it becomes the prerelease
+// of the Version; the rest of the suffix is the variant.
+func ParseTag(name string) (Tag, bool) {
+	core, suffix, hasSuffix := strings.Cut(strings.TrimPrefix(name, "v"), "-")
+	if hasSuffix && !validSuffix(suffix) {
+		return Tag{}, false
+	}
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Tag{}, false
+	}
+	var components [3]int
+	for i, part := range parts {
+		n, ok := parseComponent(part)
+		if !ok {
+			return Tag{}, false
+		}
+		components[i] = n
+	}
+	tag := Tag{Name: name, Components: len(parts), Variant: suffix}
+	s := fmt.Sprintf("%d.%d.%d", components[0], components[1], components[2])
+	if first, rest, _ := strings.Cut(suffix, "-"); isPrerelease(first) {
+		s += "-" + first
+		tag.Variant = rest
+	}
+	version, err := semver.Parse(s)
+	if err != nil {
+		return Tag{}, false
+	}
+	tag.Version = version
+	return tag, true
+}
+
+// Classify interprets every tag in a list, returning the version tags in the same order as the input,
+// and the names of the tags that are not versions.
+func Classify(names []string) (tags []Tag, ignored []string) {
+	for _, name := range names {
+		if tag, ok := ParseTag(name); ok {
+			tags = append(tags, tag)
+		} else {
+			ignored = append(ignored, name)
+		}
+	}
+	return tags, ignored
+}
+
+// IsFloating returns true if the tag has fewer than three numeric components, such as "1.25".
+func (t Tag) IsFloating() bool {
+	return t.Components < 3
+}
+
+// ComparePrecedence compares this Tag to another Tag. It returns -1 if t has lower precedence than other,
+// 1 if t has higher precedence, or 0 if the same. Tags are compared by Version, and then by Components,
+// so that "1.25" has lower precedence than "1.25.0"; the variant has no effect on precedence.
+func (t Tag) ComparePrecedence(other Tag) int {
+	if d := t.Version.ComparePrecedence(other.Version); d != 0 {
+		return d
+	}
+	switch {
+	case t.Components < other.Components:
+		return -1
+	case t.Components > other.Components:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// String returns the original tag.
+func (t Tag) String() string {
+	return t.Name
+}
+
+// Covers returns true if the other tag has the same variant as this one and belongs to the series that
+// this tag represents: for instance, "1.25-alpine" covers "1.25.3-alpine" and "1.25.0-rc.1-alpine", but
+// not "1.26.0-alpine" or "1.25.3". A tag with three components covers only tags with the same version.
+func (t Tag) Covers(other Tag) bool {
+	if t.Variant != other.Variant {
+		return false
+	}
+	v, o := t.Version, other.Version
+	switch t.Components {
+	case 1:
+		return v.GetMajor() == o.GetMajor()
+	case 2:
+		return v.GetMajor() == o.GetMajor() && v.GetMinor() == o.GetMinor()
+	default:
+		return v.ComparePrecedence(o) == 0
+	}
+}
+
+// GroupByVariant groups tags by variant. The tags in each group are sorted in ascending order of
+// precedence; tags with the same precedence, such as "1.25.0" and "1.25", are sorted with the less
+// specific ones first.
+func GroupByVariant(tags []Tag) map[string][]Tag {
+	groups := make(map[string][]Tag)
+	for _, tag := range tags {
+		groups[tag.Variant] = append(groups[tag.Variant], tag)
+	}
+	for _, group := range groups {
+		semver.Sort(group)
+	}
+	return groups
+}
+
+// Resolve returns the tag that a floating tag most likely refers to: the tag with the highest precedence
+// among the non-floating, non-prerelease tags that it covers. For instance, if the tags include "1.25",
+// "1.25.2" and "1.25.3", then "1.25" resolves to "1.25.3". If the tag is not floating, or if there are no
+// such tags, it returns the tag itself.
+func Resolve(tag Tag, tags []Tag) Tag {
+	best := tag
+	found := false
+	for _, other := range tags {
+		if !tag.IsFloating() || other.IsFloating() || other.Version.GetPrerelease() != "" || !tag.Covers(other) {
+			continue
+		}
+		if !found || other.Version.ComparePrecedence(best.Version) > 0 {
+			best, found = other, true
+		}
+	}
+	return best
+}
+
+// Policy describes which tags are acceptable, for Select.
+type Policy struct {
+	// Constraint is the set of acceptable versions, such as a semver.Range. A nil Constraint accepts
+	// every version.
+	Constraint semver.Set[semver.Version]
+
+	// Variant is the required variant; the default of "" means tags with no variant.
+	Variant string
+
+	// Prereleases allows tags whose Version has a prerelease, such as "1.26.0-rc.1".
+	Prereleases bool
+}
+
+// Select returns the best tag that satisfies the policy, or false if there is none.
+//
+// Each floating tag is treated as having the version that it resolves to (see Resolve), so that a
+// floating tag such as "1.25" is considered to be "1.25.3" rather than "1.25.0" if "1.25.3" is in the
+// list. The best tag is the one with the highest version; if a floating tag and a non-floating tag have
+// the same version, the non-floating tag is preferred, because its contents will not change.
+func (p Policy) Select(tags []Tag) (Tag, bool) {
+	var best, bestEffective Tag
+	found := false
+	for _, tag := range tags {
+		if tag.Variant != p.Variant {
+			continue
+		}
+		effective := Resolve(tag, tags)
+		if effective.Version.GetPrerelease() != "" && !p.Prereleases {
+			continue
+		}
+		if p.Constraint != nil && !p.Constraint.Contains(effective.Version) {
+			continue
+		}
+		if found {
+			d := effective.Version.ComparePrecedence(bestEffective.Version)
+			if d < 0 || (d == 0 && tag.Components <= best.Components) {
+				continue
+			}
+		}
+		best, bestEffective, found = tag, effective, true
+	}
+	return best, found
+}
+
+// SelectFrom is a shortcut for classifying a list of tag names and then calling Select.
+func (p Policy) SelectFrom(names []string) (Tag, bool) {
+	tags, _ := Classify(names)
+	return p.Select(tags)
+}
+
+// parseComponent parses a numeric component of a tag. Unlike in semantic versions, leading zeroes are
+// allowed, because calendar-based tags such as "20.04" are common.
+func parseComponent(s string) (int, bool) {
+	if s == "" || len(s) > 9 || strings.Trim(s, "0123456789") != "" {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(s)
+	return n, true
+}
+
+func isPrerelease(s string) bool {
+	for _, kind := range prereleaseKinds {
+		rest, ok := strings.CutPrefix(s, kind)
+		if !ok {
+			continue
+		}
+		if number, dotted := strings.CutPrefix(rest, "."); dotted {
+			rest = number
+			if rest == "" {
+				continue
+			}
+		}
+		if strings.Trim(rest, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func validSuffix(s string) bool {
+	for _, part := range strings.Split(s, "-") {
+		if part == "" {
+			return false
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if !(ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch == '-' || ch == '.' ||
+			ch == '_') {
+			return false
+		}
+	}
+	return true
+}
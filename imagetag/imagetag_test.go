@@ -0,0 +1,244 @@
+package imagetag
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ semver.Comparable[Tag] = Tag{}
+
+var registryTags = []string{
+	"latest", "alpine", "bookworm", "1", "1-alpine", "1.24", "1.24.9", "1.24.9-alpine", "1.25", "1.25-alpine",
+	"1.25.2", "1.25.3", "1.25.3-alpine", "1.25.3-alpine3.20", "1.25.3-bookworm", "1.26rc1", "1.26.0-rc.1",
+	"1.26.0-rc.1-alpine", "sha256-abc123.sig",
+}
+
+func mustParseRange(t *testing.T, s string) semver.Range {
+	r, err := semver.ParseRange(s)
+	require.NoError(t, err)
+	return r
+}
+
+func TestParseTag(t *testing.T) {
+	for _, test := range []struct {
+		name, version string
+		components    int
+		variant       string
+	}{
+		{"1.25.3", "1.25.3", 3, ""},
+		{"v1.25.3", "1.25.3", 3, ""},
+		{"1.25", "1.25.0", 2, ""},
+		{"1", "1.0.0", 1, ""},
+		{"20.04", "20.4.0", 2, ""},
+		{"2024.01.05", "2024.1.5", 3, ""},
+		{"1.25.3-alpine", "1.25.3", 3, "alpine"},
+		{"1.25.3-alpine3.20", "1.25.3", 3, "alpine3.20"},
+		{"1.25-bookworm", "1.25.0", 2, "bookworm"},
+		{"1.25.3-slim-bookworm", "1.25.3", 3, "slim-bookworm"},
+		{"1.25.3-windowsservercore_ltsc2022", "1.25.3", 3, "windowsservercore_ltsc2022"},
+		{"1.26.0-rc.1", "1.26.0-rc.1", 3, ""},
+		{"1.26.0-rc1", "1.26.0-rc1", 3, ""},
+		{"1.26.0-beta", "1.26.0-beta", 3, ""},
+		{"1.26.0-preview2-slim", "1.26.0-preview2", 3, "slim"},
+		{"1.26.0-rc.1-alpine", "1.26.0-rc.1", 3, "alpine"},
+		{"1.26.0-rcx", "1.26.0", 3, "rcx"},
+		{"1.26.0-rc.", "1.26.0", 3, "rc."},
+		{"1.26.0-development", "1.26.0", 3, "development"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			tag, ok := ParseTag(test.name)
+			require.True(t, ok)
+			assert.Equal(t, test.name, tag.Name)
+			assert.Equal(t, test.version, tag.Version.String())
+			assert.Equal(t, test.components, tag.Components)
+			assert.Equal(t, test.variant, tag.Variant)
+			assert.Equal(t, test.components < 3, tag.IsFloating())
+			assert.Equal(t, test.name, tag.String())
+		})
+	}
+}
+
+func TestParseTagIgnoresNonVersionTags(t *testing.T) {
+	for _, s := range []string{
+		"", "latest", "alpine", "v", "vv1.2.3", "1.2.3.4", "1..3", "1.2.1234567890", "1.x", "1.25.3-", "1.25.3--alpine",
+		"1.25.3-alpine-", "1.26rc1", "1.26.0+build", "1.26.0-rc.01", "1.25.3-alp@ine",
+		"sha256-abc123.sig",
+	} {
+		t.Run(s, func(t *testing.T) {
+			tag, ok := ParseTag(s)
+			assert.False(t, ok)
+			assert.Equal(t, Tag{}, tag)
+		})
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tags, ignored := Classify(registryTags)
+	assert.Equal(t, []string{"latest", "alpine", "bookworm", "1.26rc1", "sha256-abc123.sig"}, ignored)
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	assert.Equal(t, []string{
+		"1", "1-alpine", "1.24", "1.24.9", "1.24.9-alpine", "1.25", "1.25-alpine", "1.25.2", "1.25.3",
+		"1.25.3-alpine", "1.25.3-alpine3.20", "1.25.3-bookworm", "1.26.0-rc.1", "1.26.0-rc.1-alpine",
+	}, names)
+}
+
+func TestComparePrecedence(t *testing.T) {
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.25", "1.25.0", -1},
+		{"1", "1.0", -1},
+		{"1", "1.0.0", -1},
+		{"1.25.0", "1.25", 1},
+		{"1.25", "1.25", 0},
+		{"1.25-alpine", "1.25-bookworm", 0},
+		{"1.24.9", "1.25", -1},
+		{"1.26.0-rc.1", "1.25", 1},
+	} {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			a, ok := ParseTag(test.a)
+			require.True(t, ok)
+			b, ok := ParseTag(test.b)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, a.ComparePrecedence(b))
+		})
+	}
+}
+
+func TestGroupByVariant(t *testing.T) {
+	var tags []Tag
+	for _, s := range []string{
+		"1.25.3", "1.25-alpine", "1.25.0", "1.25", "1.24.9-alpine", "1.25.0-alpine", "1.25.3-alpine",
+	} {
+		tag, ok := ParseTag(s)
+		require.True(t, ok)
+		tags = append(tags, tag)
+	}
+	groups := GroupByVariant(tags)
+	names := make(map[string][]string)
+	for variant, group := range groups {
+		for _, tag := range group {
+			names[variant] = append(names[variant], tag.Name)
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"":       {"1.25", "1.25.0", "1.25.3"},
+		"alpine": {"1.24.9-alpine", "1.25-alpine", "1.25.0-alpine", "1.25.3-alpine"},
+	}, names)
+}
+
+func TestCovers(t *testing.T) {
+	for _, test := range []struct {
+		tag, other string
+		expected   bool
+	}{
+		{"1.25", "1.25.3", true},
+		{"1.25", "1.25.0-rc.1", true},
+		{"1.25", "1.25", true},
+		{"1.25", "1.26.0", false},
+		{"1.25", "1.25.3-alpine", false},
+		{"1.25-alpine", "1.25.3-alpine", true},
+		{"1", "1.25.3", true},
+		{"1", "2.0.0", false},
+		{"1.25.3", "1.25.3", true},
+		{"1.25.3", "1.25.4", false},
+	} {
+		t.Run(test.tag+" "+test.other, func(t *testing.T) {
+			tag, ok := ParseTag(test.tag)
+			require.True(t, ok)
+			other, ok := ParseTag(test.other)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, tag.Covers(other))
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tags, _ := Classify(registryTags)
+	for _, test := range []struct {
+		tag, expected string
+	}{
+		{"1.25", "1.25.3"},
+		{"1.24", "1.24.9"},
+		{"1", "1.25.3"},
+		{"1.25-alpine", "1.25.3-alpine"},
+		{"1-alpine", "1.25.3-alpine"},
+		{"1.25.2", "1.25.2"},
+		{"1.26", "1.26"},
+		{"2", "2"},
+	} {
+		t.Run(test.tag, func(t *testing.T) {
+			tag, ok := ParseTag(test.tag)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, Resolve(tag, tags).Name)
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	for _, test := range []struct {
+		description string
+		policy      Policy
+		expected    string
+	}{
+		{"no constraint", Policy{}, "1.25.3"},
+		{"caret range", Policy{Constraint: mustParseRange(t, "^1.24")}, "1.25.3"},
+		{"tilde range", Policy{Constraint: mustParseRange(t, "~1.24")}, "1.24.9"},
+		{"upper bound", Policy{Constraint: mustParseRange(t, "<1.25.3")}, "1.25.2"},
+		{"variant", Policy{Variant: "alpine"}, "1.25.3-alpine"},
+		{"variant with version suffix", Policy{Variant: "alpine3.20"}, "1.25.3-alpine3.20"},
+		{"variant with range", Policy{Constraint: mustParseRange(t, "<1.25"), Variant: "alpine"}, "1.24.9-alpine"},
+		{"prereleases", Policy{Prereleases: true}, "1.26.0-rc.1"},
+		{"prereleases with variant", Policy{Variant: "alpine", Prereleases: true}, "1.26.0-rc.1-alpine"},
+	} {
+		t.Run(test.description, func(t *testing.T) {
+			tag, ok := test.policy.SelectFrom(registryTags)
+			require.True(t, ok)
+			assert.Equal(t, test.expected, tag.Name)
+		})
+	}
+}
+
+func TestSelectNoMatch(t *testing.T) {
+	for _, policy := range []Policy{
+		{Constraint: mustParseRange(t, ">=2.0.0")},
+		{Variant: "windowsservercore"},
+		{Constraint: mustParseRange(t, ">=1.26.0-0")},
+	} {
+		_, ok := policy.SelectFrom(registryTags)
+		assert.False(t, ok)
+	}
+	_, ok := Policy{}.SelectFrom(nil)
+	assert.False(t, ok)
+}
+
+func TestSelectResolvesFloatingTags(t *testing.T) {
+	// "1.25" is considered to be "1.25.3", so it satisfies a range that "1.25.0" would not
+	tag, ok := Policy{Constraint: mustParseRange(t, ">=1.25.3")}.SelectFrom([]string{"1.25", "1.25.3-alpine"})
+	assert.False(t, ok)
+	tag, ok = Policy{Constraint: mustParseRange(t, ">=1.25.3")}.SelectFrom([]string{"1.25", "1.25.3"})
+	require.True(t, ok)
+	assert.Equal(t, "1.25.3", tag.Name)
+
+	// a non-floating tag is preferred over a floating tag that resolves to the same version, and a more
+	// specific floating tag is preferred over a less specific one
+	tag, ok = Policy{}.SelectFrom([]string{"1", "1.25", "1.25.3", "1.24.9"})
+	require.True(t, ok)
+	assert.Equal(t, "1.25.3", tag.Name)
+	tag, ok = Policy{}.SelectFrom([]string{"1", "1.25"})
+	require.True(t, ok)
+	assert.Equal(t, "1.25", tag.Name)
+
+	// a floating tag that resolves to a higher version than any non-floating tag it does not cover wins
+	tag, ok = Policy{Constraint: mustParseRange(t, "^1.25")}.SelectFrom([]string{"1.26", "1.25.3", "1.24"})
+	require.True(t, ok)
+	assert.Equal(t, "1.26", tag.Name)
+}
@@ -0,0 +1,86 @@
+package semver
+
+import "slices"
+
+// IntervalIndex associates sets of versions with values, and finds the values whose sets contain a given
+// version in logarithmic time. It is meant for tables such as a compatibility matrix, where each row
+// applies to a range of versions: for instance, "from 1.4.0 until 2.0.0, use protocol B".
+//
+// The index divides the versions into segments at every bound of every set, and stores with each segment
+// the values of the sets that cover it, so that a lookup is a binary search for a segment. Memory use is
+// therefore proportional to the number of sets multiplied by the number of segments that each one covers.
+//
+// An IntervalIndex is created by NewIntervalIndex, and is immutable and safe for concurrent use. The zero
+// value contains no sets.
+type IntervalIndex[V any] struct {
+	bounds   []Version // in ascending order; segment i is from bounds[i-1] (inclusive) to bounds[i] (exclusive)
+	segments [][]V     // len(bounds)+1 segments, the first and last of which are unbounded
+}
+
+// IntervalEntry is one of the entries of an IntervalIndex.
+type IntervalEntry[V any] struct {
+	Set   VersionSet
+	Value V
+}
+
+// NewIntervalIndex creates an IntervalIndex from entries, each of which associates a set of versions with
+// a value. The same value may be associated with more than one set.
+func NewIntervalIndex[V any](entries ...IntervalEntry[V]) IntervalIndex[V] {
+	var bounds []Version
+	for _, e := range entries {
+		for _, sp := range e.Set.spans {
+			if sp.hasLower {
+				bounds = append(bounds, sp.lower)
+			}
+			if sp.hasUpper {
+				bounds = append(bounds, sp.upper)
+			}
+		}
+	}
+	slices.SortFunc(bounds, Version.ComparePrecedence)
+	bounds = slices.Compact(bounds) // bounds never have build metadata, so equal precedence means equal
+
+	x := IntervalIndex[V]{bounds: bounds, segments: make([][]V, len(bounds)+1)}
+	for _, e := range entries {
+		for _, sp := range e.Set.spans {
+			first, last := 0, len(bounds)
+			if sp.hasLower {
+				first = x.segmentOf(sp.lower)
+			}
+			if sp.hasUpper {
+				last = x.segmentOf(sp.upper) - 1
+			}
+			for i := first; i <= last; i++ {
+				x.segments[i] = append(x.segments[i], e.Value)
+			}
+		}
+	}
+	return x
+}
+
+// Lookup returns the values of every entry whose set contains the version, in the order in which the
+// entries were given to NewIntervalIndex. The caller must not modify the returned slice's elements.
+//
+// Lookup does not allocate memory.
+func (x IntervalIndex[V]) Lookup(v Version) []V {
+	if len(x.segments) == 0 {
+		return nil
+	}
+	segment := x.segments[x.segmentOf(v)]
+	return segment[:len(segment):len(segment)]
+}
+
+// segmentOf returns the index of the segment that contains the version, which is the number of bounds that
+// are less than or equal to it.
+func (x IntervalIndex[V]) segmentOf(v Version) int {
+	lo, hi := 0, len(x.bounds)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if x.bounds[mid].ComparePrecedence(v) <= 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
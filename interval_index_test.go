@@ -0,0 +1,69 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntervalIndexLookup(t *testing.T) {
+	x := NewIntervalIndex(
+		IntervalEntry[string]{mustParseVersionSet(t, "<1.4.0"), "protocol A"},
+		IntervalEntry[string]{mustParseVersionSet(t, ">=1.4.0 <2.0.0"), "protocol B"},
+		IntervalEntry[string]{mustParseVersionSet(t, ">=2.0.0"), "protocol C"},
+		IntervalEntry[string]{mustParseVersionSet(t, "1.x || >=3.0.0"), "feature X"},
+		IntervalEntry[string]{mustParseVersionSet(t, "1.4.2"), "bug Y"},
+		IntervalEntry[string]{mustParseVersionSet(t, "<0.0.0-0"), "never"},
+	)
+	for _, test := range []struct {
+		version  string
+		expected []string
+	}{
+		{"0.1.0", []string{"protocol A"}},
+		{"1.0.0-0", []string{"protocol A", "feature X"}},
+		{"1.3.9", []string{"protocol A", "feature X"}},
+		{"1.4.0", []string{"protocol B", "feature X"}},
+		{"1.4.2", []string{"protocol B", "feature X", "bug Y"}},
+		{"1.4.2+build", []string{"protocol B", "feature X", "bug Y"}},
+		{"1.4.3-0", []string{"protocol B", "feature X"}},
+		{"2.0.0-beta", []string{"protocol B"}},
+		{"2.0.0", []string{"protocol C"}},
+		{"3.0.0", []string{"protocol C", "feature X"}},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			assert.Equal(t, test.expected, x.Lookup(mustParse(t, test.version)))
+		})
+	}
+}
+
+func TestIntervalIndexIsEquivalentToVersionSets(t *testing.T) {
+	var entries []IntervalEntry[int]
+	for i, test := range rangeTests {
+		entries = append(entries, IntervalEntry[int]{mustParseVersionSet(t, test.rangeString), i})
+	}
+	x := NewIntervalIndex(entries...)
+	for _, v := range versionSetTestVersions(t) {
+		var expected []int
+		for _, e := range entries {
+			if e.Set.Contains(v) {
+				expected = append(expected, e.Value)
+			}
+		}
+		assert.Equal(t, expected, x.Lookup(v), "version %s", v)
+	}
+}
+
+func TestIntervalIndexLookupResultCannotBeAppendedTo(t *testing.T) {
+	x := NewIntervalIndex(
+		IntervalEntry[int]{mustParseVersionSet(t, "*"), 1},
+		IntervalEntry[int]{mustParseVersionSet(t, "<2.0.0"), 2},
+	)
+	v := mustParse(t, "3.0.0")
+	_ = append(x.Lookup(v), 3)
+	assert.Equal(t, []int{1}, x.Lookup(v))
+}
+
+func TestZeroIntervalIndexContainsNothing(t *testing.T) {
+	assert.Nil(t, IntervalIndex[int]{}.Lookup(Version{}))
+	assert.Nil(t, NewIntervalIndex[int]().Lookup(Version{}))
+}
@@ -0,0 +1,112 @@
+//go:build go1.23
+
+package semver
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// This file requires Go 1.23 or later, for range-over-func iterators. The rest of the package still
+// builds with the minimum Go version in go.mod.
+
+// PrereleaseIdentifiers returns an iterator over the dot-separated identifiers of the prerelease
+// component: for instance, "alpha" and "1" for "1.0.0-alpha.1". It yields nothing if there is no
+// prerelease component.
+func (v Version) PrereleaseIdentifiers() iter.Seq[string] {
+	return identifiers(v.prerelease)
+}
+
+// BuildIdentifiers returns an iterator over the dot-separated identifiers of the build component: for
+// instance, "build" and "007" for "1.0.0+build.007". It yields nothing if there is no build component.
+func (v Version) BuildIdentifiers() iter.Seq[string] {
+	return identifiers(v.build)
+}
+
+func identifiers(s string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for rest := s; rest != ""; {
+			var identifier string
+			identifier, rest, _ = strings.Cut(rest, ".")
+			if !yield(identifier) {
+				return
+			}
+		}
+	}
+}
+
+// Ascending returns an iterator over versions of any Comparable type in ascending order of precedence,
+// without modifying the slice. Versions with the same precedence are yielded in their original order.
+//
+// The versions are copied and sorted each time the iterator is used, so later changes to the slice are
+// reflected in later iterations.
+func Ascending[T Comparable[T]](versions []T) iter.Seq[T] {
+	return sortedVersions(versions, 1)
+}
+
+// Descending is like Ascending, but yields the versions in descending order of precedence. Versions with
+// the same precedence are still yielded in their original order.
+func Descending[T Comparable[T]](versions []T) iter.Seq[T] {
+	return sortedVersions(versions, -1)
+}
+
+func sortedVersions[T Comparable[T]](versions []T, direction int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		sorted := slices.Clone(versions)
+		slices.SortStableFunc(sorted, func(a, b T) int { return a.ComparePrecedence(b) * direction })
+		for _, v := range sorted {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Satisfying returns an iterator that yields the versions from another iterator that are in the
+// specified set, such as a Range or VersionSet. A nil set includes every version.
+//
+// The filtering is lazy: each version is tested only when the iteration reaches it, so Satisfying can be
+// used with iterators that are expensive or infinite.
+func Satisfying[T any](versions iter.Seq[T], set Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range versions {
+			if (set == nil || set.Contains(v)) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the entries of the map in ascending order of precedence. The map must not
+// be modified during the iteration, except by setting the value of an existing key.
+func (m *OrderedMap[V]) All() iter.Seq2[Version, V] {
+	return func(yield func(Version, V) bool) {
+		m.Ascend(yield)
+	}
+}
+
+// Keys returns an iterator over the keys of the map in ascending order of precedence. The same rules as
+// for All apply to modifying the map.
+func (m *OrderedMap[V]) Keys() iter.Seq[Version] {
+	return func(yield func(Version) bool) {
+		m.Ascend(func(key Version, _ V) bool { return yield(key) })
+	}
+}
+
+// Values returns an iterator over the values of the map in ascending order of precedence of their keys.
+// The same rules as for All apply to modifying the map.
+func (m *OrderedMap[V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.Ascend(func(_ Version, value V) bool { return yield(value) })
+	}
+}
+
+// Between returns an iterator over the entries of the map whose keys are greater than or equal to from
+// and less than to, in ascending order of precedence, like AscendRange. The same rules as for All apply to
+// modifying the map.
+func (m *OrderedMap[V]) Between(from, to Version) iter.Seq2[Version, V] {
+	return func(yield func(Version, V) bool) {
+		m.AscendRange(from, to, yield)
+	}
+}
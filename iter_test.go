@@ -0,0 +1,146 @@
+//go:build go1.23
+
+package semver
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrereleaseAndBuildIdentifiers(t *testing.T) {
+	for _, test := range []struct {
+		version           string
+		prerelease, build []string
+	}{
+		{"1.0.0", nil, nil},
+		{"1.0.0-alpha", []string{"alpha"}, nil},
+		{"1.0.0-alpha.1", []string{"alpha", "1"}, nil},
+		{"1.0.0-x.7.z.92+build.007", []string{"x", "7", "z", "92"}, []string{"build", "007"}},
+		{"1.0.0+20130313144700", nil, []string{"20130313144700"}},
+		{"1.0.0-a-b.-+exp-sha.5114f85", []string{"a-b", "-"}, []string{"exp-sha", "5114f85"}},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			v := mustParse(t, test.version)
+			assert.Equal(t, test.prerelease, slices.Collect(v.PrereleaseIdentifiers()))
+			assert.Equal(t, test.build, slices.Collect(v.BuildIdentifiers()))
+
+			// the iterator can be used more than once
+			assert.Equal(t, test.prerelease, slices.Collect(v.PrereleaseIdentifiers()))
+		})
+	}
+}
+
+func TestIdentifiersStopEarly(t *testing.T) {
+	var identifiers []string
+	for id := range mustParse(t, "1.0.0-a.b.c.d").PrereleaseIdentifiers() {
+		if id == "c" {
+			break
+		}
+		identifiers = append(identifiers, id)
+	}
+	assert.Equal(t, []string{"a", "b"}, identifiers)
+}
+
+func TestAscendingAndDescending(t *testing.T) {
+	versions := []Version{
+		mustParse(t, "2.0.0"), mustParse(t, "1.0.0+b"), mustParse(t, "1.0.0-beta"), mustParse(t, "1.0.0+a"),
+		mustParse(t, "1.10.0"),
+	}
+	original := slices.Clone(versions)
+	collect := func(seq func(func(Version) bool)) []string {
+		var ret []string
+		for v := range seq {
+			ret = append(ret, v.String())
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"1.0.0-beta", "1.0.0+b", "1.0.0+a", "1.10.0", "2.0.0"}, collect(Ascending(versions)))
+	assert.Equal(t, []string{"2.0.0", "1.10.0", "1.0.0+b", "1.0.0+a", "1.0.0-beta"}, collect(Descending(versions)))
+	assert.Equal(t, original, versions)
+
+	var first []string
+	for v := range Ascending(versions) {
+		first = append(first, v.String())
+		break
+	}
+	assert.Equal(t, []string{"1.0.0-beta"}, first)
+}
+
+func TestSatisfyingIsLazy(t *testing.T) {
+	r, err := ParseRange("^1.2.0")
+	assert.NoError(t, err)
+
+	// an infinite sequence of versions 1.0.0, 1.1.0, 1.2.0, ...
+	visited := 0
+	minors := func(yield func(Version) bool) {
+		for minor := 0; ; minor++ {
+			visited++
+			if !yield(Version{major: 1, minor: minor}) {
+				return
+			}
+		}
+	}
+	var matching []string
+	for v := range Satisfying(minors, Set[Version](r)) {
+		matching = append(matching, v.String())
+		if len(matching) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"1.2.0", "1.3.0", "1.4.0"}, matching)
+	assert.Equal(t, 5, visited)
+}
+
+func TestSatisfyingWithGenericTypes(t *testing.T) {
+	versions := []ExtendedVersion{
+		mustParseExtended(t, "1.2.3.4"), mustParseExtended(t, "2.0"), mustParseExtended(t, "1.2.3.5"),
+	}
+	set := NewRangeOf([]Constraint[ExtendedVersion]{AtLeast(mustParseExtended(t, "1.2.3.5"))})
+	var matching []string
+	for v := range Satisfying(slices.Values(versions), Set[ExtendedVersion](set)) {
+		matching = append(matching, v.String())
+	}
+	assert.Equal(t, []string{"2.0", "1.2.3.5"}, matching)
+
+	matching = nil
+	for v := range Satisfying(Ascending(versions), Set[ExtendedVersion](set)) {
+		matching = append(matching, v.String())
+	}
+	assert.Equal(t, []string{"1.2.3.5", "2.0"}, matching)
+
+	assert.Len(t, slices.Collect(Satisfying(slices.Values(versions), nil)), 3)
+}
+
+func TestOrderedMapIterators(t *testing.T) {
+	var m OrderedMap[int]
+	for i, s := range []string{"2.0.0", "1.0.0", "1.5.0", "1.5.0-rc.1"} {
+		m.Set(mustParse(t, s), i)
+	}
+	var keys []string
+	var values []int
+	for k, v := range m.All() {
+		keys = append(keys, k.String())
+		values = append(values, v)
+	}
+	assert.Equal(t, []string{"1.0.0", "1.5.0-rc.1", "1.5.0", "2.0.0"}, keys)
+	assert.Equal(t, []int{1, 3, 2, 0}, values)
+	assert.Equal(t, values, slices.Collect(m.Values()))
+	assert.Len(t, slices.Collect(m.Keys()), 4)
+
+	keys = nil
+	for k := range m.Between(mustParse(t, "1.0.1"), mustParse(t, "2.0.0")) {
+		keys = append(keys, k.String())
+	}
+	assert.Equal(t, []string{"1.5.0-rc.1", "1.5.0"}, keys)
+
+	for k := range m.Keys() {
+		assert.Equal(t, "1.0.0", k.String())
+		break
+	}
+	for v := range m.Values() {
+		assert.Equal(t, 1, v)
+		break
+	}
+}
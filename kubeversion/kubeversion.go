@@ -0,0 +1,177 @@
+// Package kubeversion parses the version strings reported by Kubernetes clusters, such as the gitVersion
+// field of the API server's /version endpoint, and compares them for capability checks.
+//
+// These strings look like semantic versions with a "v" prefix, but managed Kubernetes distributions add
+// their own suffixes, which are not prereleases in the semver sense: for instance "v1.28.3-eks-abc123"
+// or "v1.27.3-gke.100" are releases of Kubernetes 1.28.3 and 1.27.3, not prereleases of them. Development
+// builds of Kubernetes itself add the number of commits since the last tag and the git commit, as in
+// "v1.29.0-alpha.1.123+abcdef123456".
+package kubeversion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// GitVersion is a parsed Kubernetes version string.
+type GitVersion struct {
+	version     semver.Version
+	vendor      string
+	gitMetadata string
+}
+
+var errInvalidGitVersion = errors.New("invalid Kubernetes version")
+
+// upstreamPrereleases are the prerelease kinds that Kubernetes itself uses.
+var upstreamPrereleases = []string{"alpha", "beta", "rc"}
+
+// Parse attempts to parse a Kubernetes version string.
+//
+// The string consists of an optional "v"; a major, minor and (optionally) patch version; optionally, a
+// hyphen and an upstream prerelease such as "alpha.1" or "rc.0", which may be followed by another
+// numeric identifier for the number of commits since that prerelease was tagged; optionally, a hyphen
+// and a vendor suffix such as "eks-abc123" or "gke.100"; and optionally, a "+" and git metadata such as
+// a commit hash. So, for instance, the version "v1.29.0-rc.1.5-gke.2+abcdef-dirty" has the prerelease
+// "rc.1.5", the vendor suffix "gke.2", and the git metadata "abcdef-dirty".
+//
+// If parsing fails, it returns a non-nil error as the second return value, and GitVersion{} as the first.
+func Parse(s string) (GitVersion, error) {
+	var v GitVersion
+	rest := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		rest, v.gitMetadata = rest[:i], rest[i+1:]
+		if !validIdentifiers(v.gitMetadata) {
+			return GitVersion{}, fmt.Errorf("%w %q: invalid git metadata", errInvalidGitVersion, s)
+		}
+	}
+
+	numbers, suffix, hasSuffix := strings.Cut(rest, "-")
+	if strings.Count(numbers, ".") == 0 {
+		return GitVersion{}, fmt.Errorf("%w %q: must have at least major and minor versions", errInvalidGitVersion, s)
+	}
+	prerelease := ""
+	if hasSuffix {
+		var ok bool
+		prerelease, v.vendor, ok = splitPrerelease(suffix)
+		if !ok || (v.vendor != "" && !validIdentifiers(v.vendor)) {
+			return GitVersion{}, fmt.Errorf("%w %q: invalid suffix", errInvalidGitVersion, s)
+		}
+	}
+
+	version, err := semver.ParseAs(numbers, semver.ParseModeAllowMissingMinorAndPatch)
+	if err == nil && prerelease != "" {
+		version, err = semver.Parse(fmt.Sprintf("%d.%d.%d-%s",
+			version.GetMajor(), version.GetMinor(), version.GetPatch(), prerelease))
+	}
+	if err != nil {
+		return GitVersion{}, fmt.Errorf("%w %q: %w", errInvalidGitVersion, s, err)
+	}
+	v.version = version
+	return v, nil
+}
+
+// Version returns the Kubernetes version without the vendor suffix or git metadata, such as "1.28.3" for
+// "v1.28.3-eks-abc123" or "1.29.0-alpha.1.123" for "v1.29.0-alpha.1.123+abcdef".
+func (v GitVersion) Version() semver.Version {
+	return v.version
+}
+
+// Vendor returns the vendor suffix, such as "eks-abc123" for "v1.28.3-eks-abc123", or an empty string if
+// there is none.
+func (v GitVersion) Vendor() string {
+	return v.vendor
+}
+
+// GitMetadata returns whatever followed the "+", such as "abcdef" for "v1.29.0-alpha.1.123+abcdef" or
+// "k3s1" for "v1.28.3+k3s1", or an empty string if there is none.
+func (v GitVersion) GitMetadata() string {
+	return v.gitMetadata
+}
+
+// String returns the version string in normal form, with a "v" prefix and a patch version.
+func (v GitVersion) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "v%s", v.version)
+	if v.vendor != "" {
+		sb.WriteByte('-')
+		sb.WriteString(v.vendor)
+	}
+	if v.gitMetadata != "" {
+		sb.WriteByte('+')
+		sb.WriteString(v.gitMetadata)
+	}
+	return sb.String()
+}
+
+// ComparePrecedence compares the Kubernetes versions of two GitVersions, ignoring their vendor suffixes
+// and git metadata. It returns -1 if v has lower precedence than other, 1 if v has higher precedence, or 0
+// if the same.
+func (v GitVersion) ComparePrecedence(other GitVersion) int {
+	return v.version.ComparePrecedence(other.version)
+}
+
+// AtLeast returns true if the Kubernetes version is the same as or higher than min, with the same
+// semantics as the AtLeast method in the Kubernetes k8s.io/apimachinery/pkg/util/version package for a
+// version parsed with ParseSemantic, except that vendor suffixes are ignored: so "v1.28.3-eks-abc123" is
+// at least "1.28.3", but "v1.29.0-alpha.1" is not at least "1.29.0". Use a minimum such as "1.29.0-0"
+// to include prereleases.
+func (v GitVersion) AtLeast(min semver.Version) bool {
+	return v.version.ComparePrecedence(min) >= 0
+}
+
+// LessThan returns true if the Kubernetes version is lower than other; it is the opposite of AtLeast.
+func (v GitVersion) LessThan(other semver.Version) bool {
+	return !v.AtLeast(other)
+}
+
+// splitPrerelease separates an upstream prerelease, if any, from the rest of the suffix.
+func splitPrerelease(suffix string) (prerelease, vendor string, ok bool) {
+	for _, kind := range upstreamPrereleases {
+		rest, found := strings.CutPrefix(suffix, kind+".")
+		if !found {
+			continue
+		}
+		// there must be a number, and then there may be another number
+		end := leadingDigits(rest)
+		if end == 0 {
+			continue
+		}
+		if end < len(rest) && rest[end] == '.' {
+			if n := leadingDigits(rest[end+1:]); n > 0 {
+				end += 1 + n
+			}
+		}
+		switch {
+		case end == len(rest):
+			return suffix, "", true
+		case rest[end] == '-':
+			vendor = rest[end+1:]
+			return suffix[:len(kind)+1+end], vendor, vendor != ""
+		}
+	}
+	return "", suffix, suffix != ""
+}
+
+func leadingDigits(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+func validIdentifiers(s string) bool {
+	if s == "" || strings.HasPrefix(s, ".") || strings.HasSuffix(s, ".") || strings.Contains(s, "..") {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if !(ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch == '-' || ch == '.') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package kubeversion
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ semver.Comparable[GitVersion] = GitVersion{}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		input, version, vendor, git, normalized string
+	}{
+		{"v1.28.3", "1.28.3", "", "", "v1.28.3"},
+		{"1.28.3", "1.28.3", "", "", "v1.28.3"},
+		{"v1.28", "1.28.0", "", "", "v1.28.0"},
+		{"v1.28.3-eks-abc123", "1.28.3", "eks-abc123", "", "v1.28.3-eks-abc123"},
+		{"v1.27.3-gke.100", "1.27.3", "gke.100", "", "v1.27.3-gke.100"},
+		{"v1.28.3+k3s1", "1.28.3", "", "k3s1", "v1.28.3+k3s1"},
+		{"v1.29.0-alpha.1", "1.29.0-alpha.1", "", "", "v1.29.0-alpha.1"},
+		{"v1.29.0-alpha.1.123+abcdef", "1.29.0-alpha.1.123", "", "abcdef", "v1.29.0-alpha.1.123+abcdef"},
+		{"v1.29.0-rc.0", "1.29.0-rc.0", "", "", "v1.29.0-rc.0"},
+		{"v1.29.0-beta.2.7+abcdef-dirty", "1.29.0-beta.2.7", "", "abcdef-dirty", "v1.29.0-beta.2.7+abcdef-dirty"},
+		{"v1.29.0-rc.1.5-gke.2+abcdef", "1.29.0-rc.1.5", "gke.2", "abcdef", "v1.29.0-rc.1.5-gke.2+abcdef"},
+		{"v1.29.0-rc.1-gke.2", "1.29.0-rc.1", "gke.2", "", "v1.29.0-rc.1-gke.2"},
+		{"v1.29.0-alpha", "1.29.0", "alpha", "", "v1.29.0-alpha"},
+		{"v1.29.0-alpha.x", "1.29.0", "alpha.x", "", "v1.29.0-alpha.x"},
+		{"v1.29.0-alphabet.1", "1.29.0", "alphabet.1", "", "v1.29.0-alphabet.1"},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.version, v.Version().String())
+			assert.Equal(t, test.vendor, v.Vendor())
+			assert.Equal(t, test.git, v.GitMetadata())
+			assert.Equal(t, test.normalized, v.String())
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", "v", "v1", "vv1.28.3", "v1.28.3-", "v1.28.3+", "v1.28.3-rc.1-", "v1.28.3-eks..1", "v01.28.3",
+		"v1.28.3.4", "v1.x", "v1.28.3-alpha.01", "v1.28.3+a+b", "v1.28.3-eks abc", "V1.28.3",
+		"v1.26.5-aks_1",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := Parse(s)
+			assert.Error(t, err)
+			assert.Equal(t, GitVersion{}, v)
+		})
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	for _, test := range []struct {
+		version, min string
+		expected     bool
+	}{
+		{"v1.28.3", "1.28.3", true},
+		{"v1.28.3", "1.28.4", false},
+		{"v1.28.3-eks-abc123", "1.28.3", true},
+		{"v1.28.3-eks-abc123", "1.28.0", true},
+		{"v1.27.9-gke.100", "1.28.0", false},
+		{"v1.28.3+k3s1", "1.28.3", true},
+		{"v1.29.0-alpha.1", "1.29.0", false},
+		{"v1.29.0-alpha.1", "1.29.0-0", true},
+		{"v1.29.0-alpha.1.123+abcdef", "1.29.0-alpha.1", true},
+		{"v1.29.0-alpha.1", "1.29.0-alpha.2", false},
+		{"v1.29.0-rc.0", "1.29.0-beta.3", true},
+		{"v2.0.0", "1.29.0", true},
+	} {
+		t.Run(test.version+" at least "+test.min, func(t *testing.T) {
+			v, err := Parse(test.version)
+			require.NoError(t, err)
+			min, err := semver.Parse(test.min)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v.AtLeast(min))
+			assert.Equal(t, !test.expected, v.LessThan(min))
+		})
+	}
+}
+
+func TestComparePrecedenceIgnoresVendorAndGitMetadata(t *testing.T) {
+	a, err := Parse("v1.28.3-eks-abc123")
+	require.NoError(t, err)
+	b, err := Parse("v1.28.3+k3s1")
+	require.NoError(t, err)
+	c, err := Parse("v1.28.4-gke.1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, a.ComparePrecedence(b))
+	assert.Equal(t, -1, b.ComparePrecedence(c))
+	assert.Equal(t, 1, c.ComparePrecedence(a))
+}
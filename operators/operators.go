@@ -0,0 +1,143 @@
+// Package operators implements semantic version clause operators in the style of LaunchDarkly feature
+// flag targeting rules, which compare a context attribute value against one or more clause values.
+//
+// # Parsing
+//
+// Both attribute values and clause values are parsed with semver.ParseModeAllowMissingMinorAndPatch, so
+// "2" and "2.1" are treated as "2.0.0" and "2.1.0". A "v" prefix is not allowed. A value may be a string
+// or a json.Number (as produced by a json.Decoder with UseNumber), which is treated as its string form.
+//
+// # Invalid input
+//
+// The operators fail closed: invalid input never causes an error, only a non-match.
+//
+//   - If the attribute value is not a string, json.Number, or []any, or is a string that is not a valid
+//     version, the clause does not match.
+//   - If the attribute value is a []any, the clause matches if it matches for any element of the slice.
+//     Elements are evaluated as described above, except that nested slices never match.
+//   - Each clause value that is not a string or json.Number, or is not a valid version (or range, for
+//     SemVerInRange), is skipped. The clause matches if it matches for any of the remaining clause values,
+//     so if every clause value is invalid, the clause does not match.
+//   - An unknown Operator never matches.
+//
+// None of this is affected by the clause being negated; negation, if any, should be applied by the
+// caller to the result of Match.
+package operators
+
+import (
+	"encoding/json"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// Operator is the name of a semantic version clause operator.
+type Operator string
+
+const (
+	// SemVerEqual matches if the attribute version has the same precedence as a clause version.
+	SemVerEqual Operator = "semVerEqual"
+
+	// SemVerLessThan matches if the attribute version has lower precedence than a clause version.
+	SemVerLessThan Operator = "semVerLessThan"
+
+	// SemVerGreaterThan matches if the attribute version has higher precedence than a clause version.
+	SemVerGreaterThan Operator = "semVerGreaterThan"
+
+	// SemVerInRange matches if the attribute version is in a clause value's range, as defined by
+	// semver.ParseRange (for instance, ">=1.2.0 <2.0.0 || ^3.1").
+	SemVerInRange Operator = "semVerInRange"
+
+	// SemVerCompatibleWith matches if the attribute version is compatible with a clause version
+	// according to the caret rule: it has equal or higher precedence, and the same leftmost nonzero
+	// component. This is equivalent to SemVerInRange with a range of "^" followed by the clause version.
+	SemVerCompatibleWith Operator = "semVerCompatibleWith"
+
+	// SemVerIsPrerelease matches if the attribute version has a prerelease component. Clause values are
+	// ignored.
+	SemVerIsPrerelease Operator = "semVerIsPrerelease"
+)
+
+// IsKnown returns true if the operator is one of those defined by this package.
+func (op Operator) IsKnown() bool {
+	switch op {
+	case SemVerEqual, SemVerLessThan, SemVerGreaterThan, SemVerInRange, SemVerCompatibleWith, SemVerIsPrerelease:
+		return true
+	default:
+		return false
+	}
+}
+
+// Match evaluates the operator for an attribute value and a list of clause values, as described in
+// the package documentation.
+func (op Operator) Match(attributeValue any, clauseValues []any) bool {
+	if !op.IsKnown() {
+		return false
+	}
+	if values, ok := attributeValue.([]any); ok {
+		for _, value := range values {
+			if _, nested := value.([]any); !nested && op.Match(value, clauseValues) {
+				return true
+			}
+		}
+		return false
+	}
+	v, ok := parseValue(attributeValue)
+	if !ok {
+		return false
+	}
+	if op == SemVerIsPrerelease {
+		return v.GetPrerelease() != ""
+	}
+	for _, clauseValue := range clauseValues {
+		if op.matchOne(v, clauseValue) {
+			return true
+		}
+	}
+	return false
+}
+
+func (op Operator) matchOne(v semver.Version, clauseValue any) bool {
+	if op == SemVerInRange {
+		s, ok := valueString(clauseValue)
+		if !ok {
+			return false
+		}
+		r, err := semver.ParseRange(s)
+		return err == nil && r.Contains(v)
+	}
+	cv, ok := parseValue(clauseValue)
+	if !ok {
+		return false
+	}
+	switch op {
+	case SemVerEqual:
+		return v.ComparePrecedence(cv) == 0
+	case SemVerLessThan:
+		return v.ComparePrecedence(cv) < 0
+	case SemVerGreaterThan:
+		return v.ComparePrecedence(cv) > 0
+	default: // SemVerCompatibleWith
+		r, err := semver.ParseRange("^" + cv.String())
+		return err == nil && r.Contains(v)
+	}
+}
+
+func parseValue(value any) (semver.Version, bool) {
+	s, ok := valueString(value)
+	if !ok {
+		return semver.Version{}, false
+	}
+	v, err := semver.ParseAs(s, semver.ParseModeAllowMissingMinorAndPatch)
+	return v, err == nil
+}
+
+func valueString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,121 @@
+package operators
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type operatorTest struct {
+	op        Operator
+	attribute any
+	clause    []any
+	expected  bool
+}
+
+var operatorTests = []operatorTest{
+	// these cases are the same as the semver operator tests in the LaunchDarkly Go SDK
+	{SemVerEqual, "2.0.0", []any{"2.0.0"}, true},
+	{SemVerEqual, "2.0", []any{"2.0.0"}, true},
+	{SemVerEqual, "2-rc1", []any{"2.0.0-rc1"}, true},
+	{SemVerEqual, "2+build2", []any{"2.0.0+build2"}, true},
+	{SemVerEqual, "2.0.0", []any{"2.0.1"}, false},
+	{SemVerLessThan, "2.0.0", []any{"2.0.1"}, true},
+	{SemVerLessThan, "2.0", []any{"2.0.1"}, true},
+	{SemVerLessThan, "2.0.1", []any{"2.0.0"}, false},
+	{SemVerLessThan, "2.0.1", []any{"2.0"}, false},
+	{SemVerLessThan, "2.0.1", []any{"xbad%ver"}, false},
+	{SemVerLessThan, "2.0.0-rc", []any{"2.0.0-rc.beta"}, true},
+	{SemVerGreaterThan, "2.0.1", []any{"2.0"}, true},
+	{SemVerGreaterThan, "2.0.1", []any{"2.0.0"}, true},
+	{SemVerGreaterThan, "2.0.0", []any{"2.0.1"}, false},
+	{SemVerGreaterThan, "2.0", []any{"2.0.1"}, false},
+	{SemVerGreaterThan, "2.0.1", []any{"xbad%ver"}, false},
+	{SemVerGreaterThan, "2.0.0-rc.1", []any{"2.0.0-rc.0"}, true},
+
+	// invalid attribute values
+	{SemVerEqual, "v2.0.0", []any{"2.0.0"}, false},
+	{SemVerEqual, "2.0.0.0", []any{"2.0.0"}, false},
+	{SemVerEqual, "", []any{"2.0.0"}, false},
+	{SemVerEqual, 2, []any{"2.0.0"}, false},
+	{SemVerEqual, 2.0, []any{"2.0.0"}, false},
+	{SemVerEqual, true, []any{"2.0.0"}, false},
+	{SemVerEqual, nil, []any{"2.0.0"}, false},
+	{SemVerEqual, map[string]any{"version": "2.0.0"}, []any{"2.0.0"}, false},
+	{SemVerEqual, []string{"2.0.0"}, []any{"2.0.0"}, false},
+
+	// invalid clause values are skipped
+	{SemVerEqual, "2.0.0", nil, false},
+	{SemVerEqual, "2.0.0", []any{2, nil, "bad", "2.0.0"}, true},
+	{SemVerEqual, "2.0.0", []any{2, nil, "bad"}, false},
+	{SemVerEqual, "2.0.0", []any{[]any{"2.0.0"}}, false},
+
+	// json.Number values
+	{SemVerEqual, json.Number("2"), []any{"2.0.0"}, true},
+	{SemVerEqual, json.Number("2.1"), []any{json.Number("2.1")}, true},
+	{SemVerEqual, json.Number("2.10"), []any{"2.1.0"}, false},
+	{SemVerEqual, json.Number("2e1"), []any{"20.0.0"}, false},
+	{SemVerEqual, json.Number("-2"), []any{"-2"}, false},
+
+	// []any attribute values
+	{SemVerEqual, []any{"1.0.0", "2.0.0"}, []any{"2.0.0"}, true},
+	{SemVerEqual, []any{"bad", 3, "2"}, []any{"2.0.0"}, true},
+	{SemVerEqual, []any{"1.0.0", "3.0.0"}, []any{"2.0.0"}, false},
+	{SemVerEqual, []any{[]any{"2.0.0"}}, []any{"2.0.0"}, false},
+	{SemVerEqual, []any{}, []any{"2.0.0"}, false},
+	{SemVerIsPrerelease, []any{"1.0.0", "2.0.0-beta"}, nil, true},
+
+	// multiple clause values
+	{SemVerLessThan, "2.0.0", []any{"1.0.0", "3.0.0"}, true},
+	{SemVerGreaterThan, "2.0.0", []any{"2.0.0", "3.0.0"}, false},
+
+	{SemVerInRange, "1.5.0", []any{">=1.0.0 <2.0.0"}, true},
+	{SemVerInRange, "1.5", []any{"^1.2"}, true},
+	{SemVerInRange, "2.0.0", []any{">=1.0.0 <2.0.0"}, false},
+	{SemVerInRange, "2.0.0", []any{"~1.2", "2.x"}, true},
+	{SemVerInRange, "2.0.0", []any{"garbage", "2.x"}, true},
+	{SemVerInRange, "2.0.0", []any{"garbage"}, false},
+	{SemVerInRange, "2.0.0", []any{json.Number("2")}, true},
+	{SemVerInRange, "2.0.0", []any{2}, false},
+	{SemVerInRange, "garbage", []any{"*"}, false},
+
+	{SemVerCompatibleWith, "1.9.0", []any{"1.2.3"}, true},
+	{SemVerCompatibleWith, "1.2.3", []any{"1.2.3"}, true},
+	{SemVerCompatibleWith, "1.2.2", []any{"1.2.3"}, false},
+	{SemVerCompatibleWith, "2.0.0", []any{"1.2.3"}, false},
+	{SemVerCompatibleWith, "2.0.0-rc.1", []any{"1.2.3"}, false},
+	{SemVerCompatibleWith, "0.2.9", []any{"0.2"}, true},
+	{SemVerCompatibleWith, "0.3.0", []any{"0.2"}, false},
+	{SemVerCompatibleWith, "1.5.0", []any{"1"}, true},
+	{SemVerCompatibleWith, "1.5.0", []any{"^1"}, false},
+	{SemVerCompatibleWith, "1.5.0", []any{"bad", "2.0.0", "1.4"}, true},
+
+	{SemVerIsPrerelease, "1.0.0-beta", nil, true},
+	{SemVerIsPrerelease, "1-beta", []any{"ignored"}, true},
+	{SemVerIsPrerelease, "1.0.0+build", nil, false},
+	{SemVerIsPrerelease, "1.0.0", nil, false},
+	{SemVerIsPrerelease, "bad-beta", nil, false},
+	{SemVerIsPrerelease, 1, nil, false},
+
+	{Operator("semVerUnknown"), "2.0.0", []any{"2.0.0"}, false},
+	{Operator(""), "2.0.0", []any{"2.0.0"}, false},
+}
+
+func TestOperators(t *testing.T) {
+	for _, test := range operatorTests {
+		t.Run(fmt.Sprintf("%s %#v %#v", test.op, test.attribute, test.clause), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.op.Match(test.attribute, test.clause))
+		})
+	}
+}
+
+func TestOperatorIsKnown(t *testing.T) {
+	for _, op := range []Operator{SemVerEqual, SemVerLessThan, SemVerGreaterThan, SemVerInRange,
+		SemVerCompatibleWith, SemVerIsPrerelease} {
+		assert.True(t, op.IsKnown(), op)
+	}
+	assert.False(t, Operator("in").IsKnown())
+	assert.False(t, Operator("semverequal").IsKnown())
+}
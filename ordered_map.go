@@ -0,0 +1,195 @@
+package semver
+
+import "math/rand/v2"
+
+// OrderedMap is a map from versions to values of type V that keeps its keys in ascending order of
+// precedence, so that it can find the nearest key to a version (Floor and Ceiling) and visit the keys
+// within a span of versions (AscendRange) in logarithmic time.
+//
+// Keys are compared with ComparePrecedence, so versions that differ only in build metadata, such as
+// "1.0.0+a" and "1.0.0+b", are the same key. Set keeps the most recently used form of the key.
+//
+// It is implemented as a skip list. The zero value is an empty map that is ready to use. Like a Go map,
+// an OrderedMap is not safe for concurrent use if any goroutine modifies it.
+type OrderedMap[V any] struct {
+	head   orderedMapNode[V] // a sentinel whose next pointers are the first node at each level
+	level  int               // the number of levels in use
+	length int
+}
+
+type orderedMapNode[V any] struct {
+	key   Version
+	value V
+	next  []*orderedMapNode[V]
+}
+
+// orderedMapMaxLevel allows for up to 4^orderedMapMaxLevel entries before searches degrade.
+const orderedMapMaxLevel = 24
+
+// Len returns the number of entries in the map.
+func (m *OrderedMap[V]) Len() int {
+	return m.length
+}
+
+// Get returns the value for a key, and true, or the zero value of V and false if the key is not present.
+func (m *OrderedMap[V]) Get(key Version) (V, bool) {
+	if n := m.ceiling(key); n != nil && n.key.ComparePrecedence(key) == 0 {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set adds or replaces the value for a key.
+func (m *OrderedMap[V]) Set(key Version, value V) {
+	m.init()
+	var update [orderedMapMaxLevel]*orderedMapNode[V]
+	if n := m.predecessors(key, &update).next[0]; n != nil && n.key.ComparePrecedence(key) == 0 {
+		n.key, n.value = key, value
+		return
+	}
+	level := randomOrderedMapLevel()
+	for ; m.level < level; m.level++ {
+		update[m.level] = &m.head
+	}
+	n := &orderedMapNode[V]{key: key, value: value, next: make([]*orderedMapNode[V], level)}
+	for i := 0; i < level; i++ {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	m.length++
+}
+
+// Delete removes the entry for a key, and returns true if it was present.
+func (m *OrderedMap[V]) Delete(key Version) bool {
+	if m.level == 0 {
+		return false
+	}
+	var update [orderedMapMaxLevel]*orderedMapNode[V]
+	n := m.predecessors(key, &update).next[0]
+	if n == nil || n.key.ComparePrecedence(key) != 0 {
+		return false
+	}
+	for i := range n.next {
+		update[i].next[i] = n.next[i]
+	}
+	for m.level > 0 && m.head.next[m.level-1] == nil {
+		m.level--
+	}
+	m.length--
+	return true
+}
+
+// Floor returns the entry with the highest key whose precedence is less than or equal to that of the
+// specified version, or false if there is none.
+func (m *OrderedMap[V]) Floor(v Version) (Version, V, bool) {
+	n := &m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for n.next[i] != nil && n.next[i].key.ComparePrecedence(v) <= 0 {
+			n = n.next[i]
+		}
+	}
+	return m.entry(n)
+}
+
+// Ceiling returns the entry with the lowest key whose precedence is greater than or equal to that of the
+// specified version, or false if there is none.
+func (m *OrderedMap[V]) Ceiling(v Version) (Version, V, bool) {
+	n := m.ceiling(v)
+	if n == nil {
+		return m.entry(&m.head)
+	}
+	return m.entry(n)
+}
+
+// Min returns the entry with the lowest key, or false if the map is empty.
+func (m *OrderedMap[V]) Min() (Version, V, bool) {
+	if m.level == 0 {
+		return m.entry(&m.head)
+	}
+	return m.entry(m.head.next[0])
+}
+
+// Max returns the entry with the highest key, or false if the map is empty.
+func (m *OrderedMap[V]) Max() (Version, V, bool) {
+	n := &m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for n.next[i] != nil {
+			n = n.next[i]
+		}
+	}
+	return m.entry(n)
+}
+
+// Ascend calls fn for every entry in ascending order of precedence, until fn returns false.
+func (m *OrderedMap[V]) Ascend(fn func(key Version, value V) bool) {
+	if m.level == 0 {
+		return
+	}
+	for n := m.head.next[0]; n != nil; n = n.next[0] {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// AscendRange calls fn in ascending order of precedence for every entry whose key is greater than or
+// equal to from and less than to, until fn returns false.
+//
+// The map must not be modified during the iteration, except by setting the value of an existing key.
+func (m *OrderedMap[V]) AscendRange(from, to Version, fn func(key Version, value V) bool) {
+	for n := m.ceiling(from); n != nil && n.key.ComparePrecedence(to) < 0; n = n.next[0] {
+		if !fn(n.key, n.value) {
+			return
+		}
+	}
+}
+
+// init allocates the head node's pointers. It is only called by Set, so that the read-only methods never
+// modify the map, even when it is the zero value; they check for an empty map (level 0) instead.
+func (m *OrderedMap[V]) init() {
+	if m.head.next == nil {
+		m.head.next = make([]*orderedMapNode[V], orderedMapMaxLevel)
+	}
+}
+
+// predecessors finds the last node before the key at each level, storing them in update, and returns the
+// one at the lowest level. The map must have been initialized by Set.
+func (m *OrderedMap[V]) predecessors(key Version, update *[orderedMapMaxLevel]*orderedMapNode[V]) *orderedMapNode[V] {
+	n := &m.head
+	for i := m.level - 1; i >= 0; i-- {
+		for n.next[i] != nil && n.next[i].key.ComparePrecedence(key) < 0 {
+			n = n.next[i]
+		}
+		update[i] = n
+	}
+	return n
+}
+
+// ceiling returns the first node whose key is greater than or equal to the version, or nil.
+func (m *OrderedMap[V]) ceiling(v Version) *orderedMapNode[V] {
+	if m.level == 0 {
+		return nil
+	}
+	var update [orderedMapMaxLevel]*orderedMapNode[V]
+	return m.predecessors(v, &update).next[0]
+}
+
+// entry returns the key and value of a node, or false if it is the head.
+func (m *OrderedMap[V]) entry(n *orderedMapNode[V]) (Version, V, bool) {
+	if n == &m.head {
+		var zero V
+		return Version{}, zero, false
+	}
+	return n.key, n.value, true
+}
+
+// randomOrderedMapLevel returns a level for a new node: 1 with probability 3/4, 2 with probability 3/16,
+// and so on.
+func randomOrderedMapLevel() int {
+	level := 1
+	for r := rand.Uint64(); level < orderedMapMaxLevel && r&3 == 0; r >>= 2 {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,59 @@
+package semver
+
+import (
+	"fmt"
+	"testing"
+)
+
+var (
+	// use package-level variables so the compiler won't optimize away benchmark logic
+	benchmarkOrderedMapResult    int
+	benchmarkIntervalIndexResult []string
+)
+
+func makeBenchmarkOrderedMap(b *testing.B) (*OrderedMap[int], []Version) {
+	var m OrderedMap[int]
+	var versions []Version
+	for i := 0; i < 1000; i++ {
+		v, err := Parse(fmt.Sprintf("%d.%d.%d", i/100, i/10%10, i%10))
+		if err != nil {
+			b.Fatal(err)
+		}
+		m.Set(v, i)
+		versions = append(versions, v)
+	}
+	return &m, versions
+}
+
+func BenchmarkOrderedMapGet(b *testing.B) {
+	m, versions := makeBenchmarkOrderedMap(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchmarkOrderedMapResult, _ = m.Get(versions[i%len(versions)])
+	}
+}
+
+func BenchmarkOrderedMapFloor(b *testing.B) {
+	m, versions := makeBenchmarkOrderedMap(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, benchmarkOrderedMapResult, _ = m.Floor(versions[i%len(versions)])
+	}
+}
+
+func BenchmarkIntervalIndexLookup(b *testing.B) {
+	var entries []IntervalEntry[string]
+	for _, s := range []string{"<1.4.0", ">=1.4.0 <2.0.0", ">=2.0.0", "1.x || >=3.0.0", "1.4.2", "^2.1.0-rc.1"} {
+		r, err := ParseRange(s)
+		if err != nil {
+			b.Fatal(err)
+		}
+		entries = append(entries, IntervalEntry[string]{r.VersionSet(), s})
+	}
+	x := NewIntervalIndex(entries...)
+	versions := makeBenchmarkRangeVersions(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchmarkIntervalIndexResult = x.Lookup(versions[i%len(versions)])
+	}
+}
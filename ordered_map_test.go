@@ -0,0 +1,223 @@
+package semver
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func orderedMapKeys[V any](m *OrderedMap[V]) []string {
+	var keys []string
+	m.Ascend(func(k Version, _ V) bool {
+		keys = append(keys, k.String())
+		return true
+	})
+	return keys
+}
+
+func TestOrderedMapZeroValue(t *testing.T) {
+	var m OrderedMap[int]
+	v := mustParse(t, "1.0.0")
+	assert.Equal(t, 0, m.Len())
+	_, ok := m.Get(v)
+	assert.False(t, ok)
+	_, _, ok = m.Floor(v)
+	assert.False(t, ok)
+	_, _, ok = m.Ceiling(v)
+	assert.False(t, ok)
+	_, _, ok = m.Min()
+	assert.False(t, ok)
+	_, _, ok = m.Max()
+	assert.False(t, ok)
+	assert.False(t, m.Delete(v))
+	assert.Nil(t, orderedMapKeys(&m))
+	m.AscendRange(v, mustParse(t, "2.0.0"), func(Version, int) bool { return true })
+	assert.Equal(t, OrderedMap[int]{}, m, "reading a zero value does not modify it")
+}
+
+func TestOrderedMapZeroValueConcurrentReads(t *testing.T) {
+	// this is meant to be run with -race, which would report any write to the map
+	var m OrderedMap[int]
+	v := mustParse(t, "1.0.0")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = m.Get(v)
+			_, _, _ = m.Floor(v)
+			_, _, _ = m.Ceiling(v)
+			_, _, _ = m.Min()
+			_, _, _ = m.Max()
+			m.Ascend(func(Version, int) bool { return true })
+			m.AscendRange(v, v, func(Version, int) bool { return true })
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestOrderedMapSetGetDelete(t *testing.T) {
+	var m OrderedMap[string]
+	for _, s := range []string{"2.0.0", "1.0.0", "1.0.0-beta", "10.0.0", "1.10.0", "1.2.0"} {
+		m.Set(mustParse(t, s), "value "+s)
+	}
+	assert.Equal(t, 6, m.Len())
+	assert.Equal(t, []string{"1.0.0-beta", "1.0.0", "1.2.0", "1.10.0", "2.0.0", "10.0.0"}, orderedMapKeys(&m))
+
+	value, ok := m.Get(mustParse(t, "1.2.0"))
+	assert.True(t, ok)
+	assert.Equal(t, "value 1.2.0", value)
+	_, ok = m.Get(mustParse(t, "1.3.0"))
+	assert.False(t, ok)
+
+	// a key that differs only in build metadata replaces the existing key
+	m.Set(mustParse(t, "1.2.0+build"), "new value")
+	assert.Equal(t, 6, m.Len())
+	value, ok = m.Get(mustParse(t, "1.2.0"))
+	assert.True(t, ok)
+	assert.Equal(t, "new value", value)
+	assert.Equal(t, []string{"1.0.0-beta", "1.0.0", "1.2.0+build", "1.10.0", "2.0.0", "10.0.0"}, orderedMapKeys(&m))
+
+	assert.True(t, m.Delete(mustParse(t, "1.0.0")))
+	assert.False(t, m.Delete(mustParse(t, "1.0.0")))
+	assert.Equal(t, 5, m.Len())
+	assert.Equal(t, []string{"1.0.0-beta", "1.2.0+build", "1.10.0", "2.0.0", "10.0.0"}, orderedMapKeys(&m))
+
+	// the map can be reused after every key is deleted
+	for _, s := range []string{"1.0.0-beta", "1.2.0", "1.10.0", "2.0.0", "10.0.0"} {
+		assert.True(t, m.Delete(mustParse(t, s)))
+	}
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, m.level)
+	m.Set(mustParse(t, "3.0.0"), "value 3.0.0")
+	assert.Equal(t, []string{"3.0.0"}, orderedMapKeys(&m))
+}
+
+func TestOrderedMapFloorCeilingMinMax(t *testing.T) {
+	var m OrderedMap[int]
+	for i, s := range []string{"1.0.0", "1.4.0", "2.0.0-rc.1", "2.0.0"} {
+		m.Set(mustParse(t, s), i)
+	}
+	for _, test := range []struct {
+		version, floor, ceiling string
+	}{
+		{"0.9.0", "", "1.0.0"},
+		{"1.0.0", "1.0.0", "1.0.0"},
+		{"1.0.0+build", "1.0.0", "1.0.0"},
+		{"1.3.9", "1.0.0", "1.4.0"},
+		{"1.4.0", "1.4.0", "1.4.0"},
+		{"2.0.0-beta", "1.4.0", "2.0.0-rc.1"},
+		{"2.0.0-rc.2", "2.0.0-rc.1", "2.0.0"},
+		{"2.0.1", "2.0.0", ""},
+	} {
+		t.Run(test.version, func(t *testing.T) {
+			v := mustParse(t, test.version)
+			floor, _, ok := m.Floor(v)
+			assert.Equal(t, test.floor != "", ok)
+			if ok {
+				assert.Equal(t, test.floor, floor.String())
+			}
+			ceiling, _, ok := m.Ceiling(v)
+			assert.Equal(t, test.ceiling != "", ok)
+			if ok {
+				assert.Equal(t, test.ceiling, ceiling.String())
+			}
+		})
+	}
+	minKey, minValue, ok := m.Min()
+	require.True(t, ok)
+	assert.Equal(t, "1.0.0", minKey.String())
+	assert.Equal(t, 0, minValue)
+	maxKey, maxValue, ok := m.Max()
+	require.True(t, ok)
+	assert.Equal(t, "2.0.0", maxKey.String())
+	assert.Equal(t, 3, maxValue)
+}
+
+func TestOrderedMapAscendRange(t *testing.T) {
+	var m OrderedMap[int]
+	for i, s := range []string{"1.0.0", "1.4.0", "1.5.0-beta", "1.5.0", "2.0.0-rc.1", "2.0.0"} {
+		m.Set(mustParse(t, s), i)
+	}
+	collect := func(from, to string, limit int) []string {
+		var keys []string
+		m.AscendRange(mustParse(t, from), mustParse(t, to), func(k Version, _ int) bool {
+			keys = append(keys, k.String())
+			return len(keys) < limit
+		})
+		return keys
+	}
+	assert.Equal(t, []string{"1.4.0", "1.5.0-beta", "1.5.0"}, collect("1.4.0", "2.0.0-0", 10))
+	assert.Equal(t, []string{"1.4.0", "1.5.0-beta", "1.5.0", "2.0.0-rc.1"}, collect("1.1.0", "2.0.0", 10))
+	assert.Equal(t, []string{"1.4.0", "1.5.0-beta"}, collect("1.1.0", "2.0.0", 2))
+	assert.Nil(t, collect("1.1.0", "1.2.0", 10))
+	assert.Nil(t, collect("3.0.0", "1.0.0", 10))
+
+	var keys []string
+	m.Ascend(func(k Version, _ int) bool {
+		keys = append(keys, k.String())
+		return len(keys) < 3
+	})
+	assert.Equal(t, []string{"1.0.0", "1.4.0", "1.5.0-beta"}, keys)
+}
+
+func TestOrderedMapMatchesSortedSlice(t *testing.T) {
+	// random operations on a map are checked against a sorted slice of keys
+	r := rand.New(rand.NewPCG(1, 2))
+	randomVersion := func() Version {
+		v := Version{major: r.IntN(5), minor: r.IntN(5), patch: r.IntN(5)}
+		if r.IntN(4) == 0 {
+			v.prerelease = []string{"alpha", "beta", "rc.1", "rc.2"}[r.IntN(4)]
+		}
+		return v
+	}
+	var m OrderedMap[Version]
+	var model []Version
+	for i := 0; i < 5000; i++ {
+		v := randomVersion()
+		index, found := slices.BinarySearchFunc(model, v, Version.ComparePrecedence)
+		switch r.IntN(3) {
+		case 0, 1:
+			m.Set(v, v)
+			if !found {
+				model = slices.Insert(model, index, v)
+			}
+		default:
+			assert.Equal(t, found, m.Delete(v))
+			if found {
+				model = slices.Delete(model, index, index+1)
+			}
+		}
+		require.Equal(t, len(model), m.Len())
+
+		v = randomVersion()
+		index, found = slices.BinarySearchFunc(model, v, Version.ComparePrecedence)
+		value, ok := m.Get(v)
+		assert.Equal(t, found, ok)
+		if found {
+			assert.Equal(t, v, value)
+		}
+		floor, _, ok := m.Floor(v)
+		if found {
+			assert.True(t, ok)
+			assert.Equal(t, v, floor)
+		} else if assert.Equal(t, index > 0, ok) && ok {
+			assert.Equal(t, model[index-1], floor)
+		}
+		ceiling, _, ok := m.Ceiling(v)
+		if assert.Equal(t, index < len(model), ok) && ok {
+			assert.Equal(t, model[index], ceiling)
+		}
+	}
+	var keys []Version
+	m.Ascend(func(k Version, _ Version) bool {
+		keys = append(keys, k)
+		return true
+	})
+	assert.Equal(t, model, keys)
+}
@@ -0,0 +1,64 @@
+package semver
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// These benchmarks mirror the ones in compare_benchmark_test.go, using Packed instead of Version. Each
+// also reports the size of a single value, for comparison with BenchmarkVersionSize.
+
+func BenchmarkVersionSize(b *testing.B) {
+	b.ReportMetric(float64(unsafe.Sizeof(Version{})), "bytes/value")
+}
+
+func BenchmarkPackedSize(b *testing.B) {
+	b.ReportMetric(float64(unsafe.Sizeof(Packed{})), "bytes/value")
+}
+
+func BenchmarkPackedCompareSimple(b *testing.B) {
+	p, _ := ParsePacked("0.0.1")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchmarkCompareResult = p.ComparePrecedence(p)
+		if benchmarkCompareResult != 0 {
+			b.Fail()
+		}
+	}
+}
+
+func BenchmarkPackedCompareComplex(b *testing.B) {
+	p, _ := ParsePacked("0.0.1-alpha.preview+123.456")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchmarkCompareResult = p.ComparePrecedence(p)
+		if benchmarkCompareResult != 0 {
+			b.Fail()
+		}
+	}
+}
+
+func BenchmarkPackedCompareAverage(b *testing.B) {
+	type packedCompareTest struct {
+		p1, p2 Packed
+		result int
+	}
+	tests := make([]packedCompareTest, 0, len(compareTests))
+	for _, test := range compareTests {
+		p1, _ := Pack(test.v1)
+		p2, _ := Pack(test.v2)
+		tests = append(tests, packedCompareTest{p1, p2, test.result})
+	}
+	l := len(tests)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		test := tests[n%l]
+		benchmarkCompareResult = test.p1.ComparePrecedence(test.p2)
+		if benchmarkCompareResult != test.result {
+			b.Fail()
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package semver
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackRoundTrip(t *testing.T) {
+	for _, s := range append([]string{"0.0.0", "2097151.2097151.2097151-rc.1+b", "1.0.0+build-only"},
+		benchmarkFormatTests...) {
+		t.Run(s, func(t *testing.T) {
+			v, err := Parse(s)
+			require.NoError(t, err)
+			p, err := Pack(v)
+			require.NoError(t, err)
+			assert.Equal(t, v, p.Unpack())
+			assert.Equal(t, s, p.String())
+
+			p2, err := ParsePacked(s)
+			require.NoError(t, err)
+			assert.Equal(t, p, p2)
+		})
+	}
+}
+
+func TestPackComponentTooLarge(t *testing.T) {
+	for _, v := range []Version{{major: MaxPackedComponent + 1}, {minor: MaxPackedComponent + 1},
+		{patch: MaxPackedComponent + 1}} {
+		p, err := Pack(v)
+		assert.Error(t, err)
+		assert.Equal(t, Packed{}, p)
+	}
+
+	_, err := ParsePacked("1.2.2097152")
+	assert.Error(t, err)
+}
+
+func TestParsePackedInvalid(t *testing.T) {
+	p, err := ParsePacked("1.2")
+	assert.Error(t, err)
+	assert.Equal(t, Packed{}, p)
+}
+
+func TestPackedZeroValue(t *testing.T) {
+	assert.Equal(t, Version{}, Packed{}.Unpack())
+	p, err := Pack(Version{})
+	require.NoError(t, err)
+	assert.Equal(t, Packed{}, p)
+}
+
+func TestPackedComparePrecedence(t *testing.T) {
+	for _, test := range compareTests {
+		p1, err := Pack(test.v1)
+		require.NoError(t, err)
+		p2, err := Pack(test.v2)
+		require.NoError(t, err)
+		assert.Equal(t, test.result, p1.ComparePrecedence(p2), "%s vs %s", test.v1, test.v2)
+		assert.Equal(t, -test.result, p2.ComparePrecedence(p1), "%s vs %s", test.v2, test.v1)
+	}
+
+	// check every pair of versions, including versions whose prerelease components are equal but whose
+	// build components differ, and so are interned separately
+	versions := []Version{{}, {prerelease: "0"}, {prerelease: "alpha", build: "x"}, {prerelease: "alpha", build: "y"}}
+	for _, test := range compareTests {
+		versions = append(versions, test.v1, test.v2)
+	}
+	for _, v1 := range versions {
+		for _, v2 := range versions {
+			p1, _ := Pack(v1)
+			p2, _ := Pack(v2)
+			assert.Equal(t, v1.ComparePrecedence(v2), p1.ComparePrecedence(p2), "%s vs %s", v1, v2)
+		}
+	}
+}
+
+func TestPackedSuffixesAreInterned(t *testing.T) {
+	p1, _ := ParsePacked("1.0.0-beta.1+b")
+	p2, _ := ParsePacked("2.0.0-beta.1+b")
+	p3, _ := ParsePacked("2.0.0-beta.1")
+	p4, _ := ParsePacked("2.0.0")
+	assert.Same(t, p1.suffix, p2.suffix)
+	assert.NotSame(t, p1.suffix, p3.suffix)
+	assert.Nil(t, p4.suffix)
+}
+
+func TestPackConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	results := make([]Packed, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				results[i], _ = ParsePacked("1.0.0-concurrent.test")
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, p := range results {
+		assert.Same(t, results[0].suffix, p.suffix)
+	}
+}
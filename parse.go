@@ -1,8 +1,6 @@
 package semver
 
-import (
-	"errors"
-)
+import "errors"
 
 // ParseMode is an enum-like type used with ParseAs.
 type ParseMode int
@@ -18,6 +16,10 @@ const (
 	// version component ("2.1"), or both the minor and patch version components ("2"), in which case
 	// they are assumed to be zero.
 	ParseModeAllowMissingMinorAndPatch = iota
+
+	// ParseModeSemVer1 is a parsing mode for versions that follow the older Semantic Versioning 1.0.0
+	// standard, as described in ParseSemVer1.
+	ParseModeSemVer1 = iota
 )
 
 // Parse attempts to parse a string into a Version. It only accepts strings that strictly match the
@@ -32,6 +34,32 @@ func Parse(s string) (Version, error) {
 //
 // If parsing fails, it returns a non-nil error as the second return value, and Version{} as the first.
 func ParseAs(s string, mode ParseMode) (Version, error) {
+	if mode == ParseModeSemVer1 {
+		return ParseSemVer1(s)
+	}
+	return parseAs(s, mode)
+}
+
+// ParseBytes is equivalent to Parse, but takes a byte slice so that a version can be parsed from a
+// buffer without first converting the whole buffer to a string.
+//
+// The returned Version never retains a reference to the input, so the caller may reuse or modify the
+// buffer afterward. As a result, unlike Parse, ParseBytes allocates memory for a copy of the prerelease and
+// build components, if the version has either of them; it does not allocate otherwise.
+func ParseBytes(b []byte) (Version, error) {
+	return ParseBytesAs(b, ParseModeStrict)
+}
+
+// ParseBytesAs is equivalent to ParseAs, but takes a byte slice. The same rules as for ParseBytes apply
+// to memory use.
+func ParseBytesAs(b []byte, mode ParseMode) (Version, error) {
+	if mode == ParseModeSemVer1 {
+		return parseSemVer1(b)
+	}
+	return parseAs(b, mode)
+}
+
+func parseAs[S string | []byte](s S, mode ParseMode) (Version, error) {
 	if mode != ParseModeStrict && mode != ParseModeAllowMissingMinorAndPatch {
 		return Version{}, errors.New("invalid ParseMode")
 	}
@@ -73,16 +101,24 @@ func ParseAs(s string, mode ParseMode) (Version, error) {
 			return Version{}, errInvalidSemver
 		}
 	}
+	if term < 0 {
+		return result, nil
+	}
+
+	// The rest of the input is the prerelease and/or build components, which are kept as strings in the
+	// Version. Converting it in one piece means that a byte slice is copied only once, while converting a
+	// string is free.
+	rest := newSimpleASCIIScanner(string(scanner.source[scanner.pos:]))
 
 	if term == '-' {
-		result.prerelease, term = scanner.readUntil(plusTerminator)
+		result.prerelease, term = rest.readUntil(plusTerminator)
 		if result.prerelease == "" || term == scannerNonASCII || !validatePrerelease(result.prerelease) {
 			return Version{}, errInvalidSemver
 		}
 	}
 
 	if term == '+' {
-		result.build, term = scanner.readUntil(noTerminator)
+		result.build, term = rest.readUntil(noTerminator)
 		if result.build == "" || term == scannerNonASCII || !validateBuild(result.build) {
 			return Version{}, errInvalidSemver
 		}
@@ -91,8 +127,8 @@ func ParseAs(s string, mode ParseMode) (Version, error) {
 	return result, nil
 }
 
-func requirePositiveIntegerComponent(
-	scanner *simpleASCIIScanner,
+func requirePositiveIntegerComponent[S string | []byte](
+	scanner *simpleASCIIScanner[S],
 	terminatorFn func(rune) bool,
 ) (n int, terminatedBy int8, ok bool) {
 	// From spec:
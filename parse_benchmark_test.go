@@ -24,6 +24,20 @@ func BenchmarkParseSimple(b *testing.B) {
 	}
 }
 
+// ParseBytes only allocates for versions with a prerelease or build component, so we don't include a
+// "complex" benchmark for it, since all benchmarks are expected to be allocation-free.
+func BenchmarkParseBytesSimple(b *testing.B) {
+	version := []byte("0.0.1")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchmarkVer, benchmarkErr = ParseBytes(version)
+		if benchmarkErr != nil {
+			b.Fatal(benchmarkErr)
+		}
+	}
+}
+
 func BenchmarkParseComplex(b *testing.B) {
 	const VERSION = "0.0.1-alpha.preview+123.456"
 	b.ReportAllocs()
@@ -183,7 +183,70 @@ func parsingTestsForAnyMode(t *testing.T, parseFn func(string) (Version, error))
 }
 
 func TestParseAsUnknownMode(t *testing.T) {
-	v, err := ParseAs("1.2.3", ParseMode(2))
+	v, err := ParseAs("1.2.3", ParseMode(99))
 	assert.Error(t, err)
 	assert.Equal(t, Version{}, v)
+
+	v, err = ParseBytesAs([]byte("1.2.3"), ParseMode(99))
+	assert.Error(t, err)
+	assert.Equal(t, Version{}, v)
+}
+
+func TestParseBytesDoesNotRetainInput(t *testing.T) {
+	buf := []byte("1.2.3-beta.1+build.2")
+	v, err := ParseBytes(buf)
+	require.NoError(t, err)
+	for i := range buf {
+		buf[i] = 'x'
+	}
+	assertVersionComponents(t, v, 1, 2, 3, "beta.1", "build.2")
+}
+
+func TestParseBytes(t *testing.T) {
+	// ParseBytesAs uses the same parser as ParseAs, so rather than repeating every parsing test, we check that
+	// they agree on a representative set of inputs.
+	for _, s := range []string{
+		"1.2.3", "1.2.3-beta.1", "1.2.3+build.02", "1.2.3-beta.1+build.2", "1.2", "1-rc.1", "1+b", "0.0.0",
+		"9223372036854775807.0.0", "9223372036854775808.0.0", "", "1.2.3-", "1.2.3+", "01.2.3", "1.2.3-01",
+		"1.2.3-beta!", "1.2.3+build!", "1.2.🔥", "1.2.3-🔥", "1.2.3+🔥", "v1.2.3", "1.2.3.4", "1.2.3-a..b",
+	} {
+		for _, mode := range []ParseMode{ParseModeStrict, ParseModeAllowMissingMinorAndPatch, ParseModeSemVer1} {
+			t.Run(fmt.Sprintf("%q mode %d", s, mode), func(t *testing.T) {
+				expected, expectedErr := ParseAs(s, mode)
+				v, err := ParseBytesAs([]byte(s), mode)
+				assert.Equal(t, expected, v)
+				assert.Equal(t, expectedErr == nil, err == nil)
+				if mode == ParseModeStrict {
+					v, err = ParseBytes([]byte(s))
+					assert.Equal(t, expected, v)
+					assert.Equal(t, expectedErr == nil, err == nil)
+				}
+			})
+		}
+	}
+}
+
+func TestParseBytesAllocations(t *testing.T) {
+	// only the copy of the prerelease and build components allocates
+	for _, test := range []struct {
+		input    string
+		mode     ParseMode
+		expected float64
+	}{
+		{"1.2.3", ParseModeStrict, 0},
+		{"1.2", ParseModeAllowMissingMinorAndPatch, 0},
+		{"1.2.3", ParseModeSemVer1, 0},
+		{"1.2.3-beta1+build.1", ParseModeStrict, 1},
+		{"1.2-beta1", ParseModeAllowMissingMinorAndPatch, 1},
+		{"1.2.3beta1", ParseModeSemVer1, 1},
+		{"1.2.3-beta1", ParseModeSemVer1, 1},
+		{"1.2.x", ParseModeStrict, 0},
+		{"1.2.3beta!", ParseModeSemVer1, 0},
+		{"1.2.3+build", ParseModeSemVer1, 0},
+	} {
+		b := []byte(test.input)
+		assert.Equal(t, test.expected, testing.AllocsPerRun(100, func() {
+			benchmarkVer, benchmarkErr = ParseBytesAs(b, test.mode)
+		}), "%q mode %d", test.input, test.mode)
+	}
 }
@@ -0,0 +1,193 @@
+// Package parsecache provides an opt-in, concurrency-safe cache of semantic version parsing results,
+// for applications that repeatedly parse the same version strings.
+//
+// A Cache is divided into shards, each of which is a bounded least-recently-used cache protected by its
+// own lock, so that goroutines parsing different strings rarely contend with each other. Both successful
+// and failed parsing results are cached.
+package parsecache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+const (
+	// DefaultCapacity is the total number of entries a Cache holds if Options.Capacity is zero.
+	DefaultCapacity = 1024
+
+	// DefaultShards is the number of shards a Cache uses if Options.Shards is zero.
+	DefaultShards = 16
+)
+
+// Options contains the optional parameters for New.
+type Options struct {
+	// Capacity is the maximum number of entries, including failed results, that the cache holds, rounded
+	// up to a multiple of Shards. When a shard is full, its least recently used entry is evicted. If
+	// zero, DefaultCapacity is used.
+	Capacity int
+
+	// Shards is the number of independently locked shards, between which the capacity is divided evenly.
+	// It cannot be more than Capacity. If zero, DefaultShards is used.
+	Shards int
+
+	// Mode is the semver.ParseMode that the cache uses. The default is semver.ParseModeStrict.
+	Mode semver.ParseMode
+
+	// Hooks are optional callbacks for cache activity, such as for reporting metrics.
+	Hooks Hooks
+}
+
+// Hooks are optional callbacks that a Cache invokes synchronously as it is used. Any of them may be nil.
+// They may be called concurrently from multiple goroutines, and must not call back into the Cache.
+type Hooks struct {
+	// OnHit is called when a result is found in the cache.
+	OnHit func()
+
+	// OnMiss is called when a result is not found in the cache and must be computed.
+	OnMiss func()
+
+	// OnEvict is called when an entry is removed to make room for a new one.
+	OnEvict func()
+}
+
+// Stats is a snapshot of a Cache's cumulative activity.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// Cache is a concurrency-safe cache of parsing results. It must be created with New.
+type Cache struct {
+	shards                  []shard
+	mode                    semver.ParseMode
+	hooks                   Hooks
+	hits, misses, evictions atomic.Uint64
+}
+
+type shard struct {
+	lock     sync.Mutex
+	entries  map[string]*list.Element
+	order    list.List // front is most recently used
+	capacity int
+}
+
+type entry struct {
+	key     string
+	version semver.Version
+	err     error
+}
+
+// New creates a Cache.
+func New(opts Options) *Cache {
+	capacity, shardCount := opts.Capacity, opts.Shards
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	if shardCount <= 0 {
+		shardCount = DefaultShards
+	}
+	if shardCount > capacity {
+		shardCount = capacity
+	}
+	c := &Cache{
+		shards: make([]shard, shardCount),
+		mode:   opts.Mode,
+		hooks:  opts.Hooks,
+	}
+	for i := range c.shards {
+		c.shards[i].entries = make(map[string]*list.Element)
+		c.shards[i].capacity = (capacity + shardCount - 1) / shardCount
+	}
+	return c
+}
+
+// Parse returns the same result as semver.ParseAs(s, mode) for the Cache's parse mode, using a cached
+// result if there is one.
+//
+// The cache keeps its own copy of s, so a string that is a slice of a larger buffer does not cause the
+// buffer to be retained.
+func (c *Cache) Parse(s string) (semver.Version, error) {
+	sh := &c.shards[hashString(s)%uint32(len(c.shards))]
+	sh.lock.Lock()
+	if elem, ok := sh.entries[s]; ok {
+		sh.order.MoveToFront(elem)
+		e := elem.Value.(*entry)
+		sh.lock.Unlock()
+		c.hits.Add(1)
+		callHook(c.hooks.OnHit)
+		return e.version, e.err
+	}
+	sh.lock.Unlock()
+
+	c.misses.Add(1)
+	callHook(c.hooks.OnMiss)
+	key := strings.Clone(s)
+	v, err := semver.ParseAs(key, c.mode)
+
+	sh.lock.Lock()
+	evicted := sh.store(&entry{key: key, version: v, err: err})
+	sh.lock.Unlock()
+	if evicted {
+		c.evictions.Add(1)
+		callHook(c.hooks.OnEvict)
+	}
+	return v, err
+}
+
+// store adds an entry as the most recently used one, evicting the least recently used entry if the shard
+// is full, and returns true if it evicted an entry. The caller must hold the lock.
+func (sh *shard) store(e *entry) bool {
+	// Another goroutine may have stored the same key while we were parsing without the lock; if so, the
+	// result is the same, so we keep the existing entry, but it is now the most recently used.
+	if elem, ok := sh.entries[e.key]; ok {
+		sh.order.MoveToFront(elem)
+		return false
+	}
+	evicted := false
+	if sh.order.Len() >= sh.capacity {
+		oldest := sh.order.Back()
+		sh.order.Remove(oldest)
+		delete(sh.entries, oldest.Value.(*entry).key)
+		evicted = true
+	}
+	sh.entries[e.key] = sh.order.PushFront(e)
+	return evicted
+}
+
+// Len returns the number of entries currently in the cache.
+func (c *Cache) Len() int {
+	n := 0
+	for i := range c.shards {
+		sh := &c.shards[i]
+		sh.lock.Lock()
+		n += sh.order.Len()
+		sh.lock.Unlock()
+	}
+	return n
+}
+
+// Stats returns the cumulative number of hits, misses, and evictions since the Cache was created.
+func (c *Cache) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load(), Evictions: c.evictions.Load()}
+}
+
+func callHook(fn func()) {
+	if fn != nil {
+		fn()
+	}
+}
+
+// hashString is the 32-bit FNV-1a hash, computed without allocating.
+func hashString(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
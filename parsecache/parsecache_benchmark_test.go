@@ -0,0 +1,46 @@
+package parsecache
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+var (
+	// use package-level variables so the compiler won't optimize away benchmark logic
+	benchmarkVer semver.Version
+	benchmarkErr error
+)
+
+const benchmarkVersion = "0.0.1-alpha.preview+123.456"
+
+func BenchmarkParseWithoutCache(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		benchmarkVer, benchmarkErr = semver.Parse(benchmarkVersion)
+	}
+}
+
+func BenchmarkCacheHit(b *testing.B) {
+	c := New(Options{})
+	_, _ = c.Parse(benchmarkVersion)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		benchmarkVer, benchmarkErr = c.Parse(benchmarkVersion)
+	}
+}
+
+func BenchmarkCacheHitParallel(b *testing.B) {
+	c := New(Options{})
+	inputs := []string{"1.0.0", "1.2.3-beta.1", "2.0.0+build", benchmarkVersion, "invalid"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = c.Parse(inputs[i%len(inputs)])
+			i++
+		}
+	})
+}
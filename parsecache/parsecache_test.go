@@ -0,0 +1,162 @@
+package parsecache
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCachesSuccessfulResults(t *testing.T) {
+	c := New(Options{})
+	for i := 0; i < 3; i++ {
+		v, err := c.Parse("1.2.3-beta+b")
+		require.NoError(t, err)
+		expected, _ := semver.Parse("1.2.3-beta+b")
+		assert.Equal(t, expected, v)
+	}
+	assert.Equal(t, Stats{Hits: 2, Misses: 1}, c.Stats())
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestParseCachesFailedResults(t *testing.T) {
+	c := New(Options{})
+	for i := 0; i < 3; i++ {
+		v, err := c.Parse("not a version")
+		assert.Error(t, err)
+		assert.Equal(t, semver.Version{}, v)
+	}
+	assert.Equal(t, Stats{Hits: 2, Misses: 1}, c.Stats())
+}
+
+func TestParseUsesMode(t *testing.T) {
+	strict := New(Options{})
+	_, err := strict.Parse("2.1")
+	assert.Error(t, err)
+
+	loose := New(Options{Mode: semver.ParseModeAllowMissingMinorAndPatch})
+	v, err := loose.Parse("2.1")
+	require.NoError(t, err)
+	assert.Equal(t, "2.1.0", v.String())
+
+	invalid := New(Options{Mode: semver.ParseMode(99)})
+	_, err = invalid.Parse("1.2.3")
+	assert.Error(t, err)
+}
+
+func TestParseDoesNotRetainInput(t *testing.T) {
+	c := New(Options{})
+	buf := []byte("1.0.0-abc")
+	_, _ = c.Parse(string(buf[:9]))
+	for key := range c.shards[hashString("1.0.0-abc")%uint32(len(c.shards))].entries {
+		assert.Equal(t, "1.0.0-abc", key)
+	}
+}
+
+func TestEviction(t *testing.T) {
+	var hits, misses, evictions atomic.Int32
+	c := New(Options{Capacity: 2, Shards: 1, Hooks: Hooks{
+		OnHit:   func() { hits.Add(1) },
+		OnMiss:  func() { misses.Add(1) },
+		OnEvict: func() { evictions.Add(1) },
+	}})
+
+	_, _ = c.Parse("1.0.0")
+	_, _ = c.Parse("2.0.0")
+	_, _ = c.Parse("1.0.0") // now 2.0.0 is the least recently used
+	_, _ = c.Parse("3.0.0") // evicts 2.0.0
+	_, _ = c.Parse("1.0.0")
+	_, _ = c.Parse("2.0.0") // evicts 3.0.0
+
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, Stats{Hits: 2, Misses: 4, Evictions: 2}, c.Stats())
+	assert.Equal(t, int32(2), hits.Load())
+	assert.Equal(t, int32(4), misses.Load())
+	assert.Equal(t, int32(2), evictions.Load())
+	assert.Contains(t, c.shards[0].entries, "1.0.0")
+	assert.Contains(t, c.shards[0].entries, "2.0.0")
+}
+
+func TestStoringExistingKeyMakesItMostRecentlyUsed(t *testing.T) {
+	// This is what happens when two goroutines miss on the same key at once: the second one to finish
+	// parsing finds the first one's entry.
+	c := New(Options{Capacity: 2, Shards: 1})
+	sh := &c.shards[0]
+	assert.False(t, sh.store(&entry{key: "1.0.0"}))
+	assert.False(t, sh.store(&entry{key: "2.0.0"}))
+	existing := sh.entries["1.0.0"].Value
+
+	assert.False(t, sh.store(&entry{key: "1.0.0"}))
+	assert.Same(t, existing, sh.order.Front().Value)
+	assert.Equal(t, 2, sh.order.Len())
+
+	assert.True(t, sh.store(&entry{key: "3.0.0"})) // evicts 2.0.0, not 1.0.0
+	assert.Contains(t, sh.entries, "1.0.0")
+	assert.NotContains(t, sh.entries, "2.0.0")
+}
+
+func TestCapacityIsDividedBetweenShards(t *testing.T) {
+	c := New(Options{Capacity: 10, Shards: 4})
+	require.Len(t, c.shards, 4)
+	assert.Equal(t, 3, c.shards[0].capacity)
+
+	c = New(Options{Capacity: 2, Shards: 8})
+	assert.Len(t, c.shards, 2)
+
+	c = New(Options{})
+	assert.Len(t, c.shards, DefaultShards)
+	assert.Equal(t, DefaultCapacity/DefaultShards, c.shards[0].capacity)
+
+	c = New(Options{Capacity: 10, Shards: 4})
+	for i := 0; i < 100; i++ {
+		_, _ = c.Parse(fmt.Sprintf("%d.0.0", i))
+	}
+	assert.LessOrEqual(t, c.Len(), 12)
+}
+
+// This test is meant to be run with the race detector ("make test-race").
+func TestConcurrentParse(t *testing.T) {
+	const goroutines, iterations, distinct = 32, 2000, 300
+	var hookCalls atomic.Int64
+	hook := func() { hookCalls.Add(1) }
+	c := New(Options{Capacity: 128, Shards: 8, Hooks: Hooks{OnHit: hook, OnMiss: hook}})
+
+	inputs := make([]string, distinct)
+	for i := range inputs {
+		if i%10 == 0 {
+			inputs[i] = fmt.Sprintf("invalid.%d", i)
+		} else {
+			inputs[i] = fmt.Sprintf("1.%d.0-rc.%d", i, i%7)
+		}
+	}
+
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				s := inputs[(g*7+i*13)%distinct]
+				v, err := c.Parse(s)
+				expected, expectedErr := semver.Parse(s)
+				if v != expected || (err == nil) != (expectedErr == nil) {
+					failures.Add(1)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(0), failures.Load())
+	stats := c.Stats()
+	assert.Equal(t, uint64(goroutines*iterations), stats.Hits+stats.Misses)
+	assert.Equal(t, int64(goroutines*iterations), hookCalls.Load())
+	assert.LessOrEqual(t, c.Len(), 128)
+	assert.Greater(t, stats.Evictions, uint64(0))
+}
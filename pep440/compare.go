@@ -0,0 +1,116 @@
+package pep440
+
+import (
+	"strconv"
+)
+
+// ComparePrecedence compares this Version to another according to the PEP 440 ordering rules. It returns
+// -1 if v is lower than other, 1 if v is higher, or 0 if they are equal.
+//
+// Versions are ordered by epoch, then by release segment (where trailing zeroes are insignificant, so
+// "1.0" equals "1.0.0"), then by suffix in the order ".devN", "aN", "bN", "rcN", no suffix, ".postN".
+// Development releases of a pre-release or post-release come just before it, so that for instance
+// "1.0a1.dev1" < "1.0a1" < "1.0a1.post1.dev1" < "1.0a1.post1". Finally, a version with a local label is
+// higher than the same version without one, and local labels are compared segment by segment, with
+// numeric segments higher than alphanumeric ones.
+func (v Version) ComparePrecedence(other Version) int {
+	if d := compareInts(v.epoch, other.epoch); d != 0 {
+		return d
+	}
+	if d := compareRelease(v.release, other.release); d != 0 {
+		return d
+	}
+	if d := compareInts(v.preRank(), other.preRank()); d != 0 {
+		return d
+	}
+	if v.preKind != "" && v.preKind == other.preKind {
+		if d := compareInts(v.preNum, other.preNum); d != 0 {
+			return d
+		}
+	}
+	if d := compareInts(v.post, other.post); d != 0 { // -1 (no post-release) sorts first
+		return d
+	}
+	if d := compareInts(v.devRank(), other.devRank()); d != 0 {
+		return d
+	}
+	return compareLocal(v.local, other.local)
+}
+
+// preRank orders the pre-release segment: a development release with no pre-release or post-release
+// segment comes before all pre-releases, and a version with no pre-release segment comes after them.
+func (v Version) preRank() int {
+	switch {
+	case v.preKind == "a":
+		return 1
+	case v.preKind == "b":
+		return 2
+	case v.preKind == "rc":
+		return 3
+	case v.post < 0 && v.dev >= 0:
+		return 0
+	default:
+		return 4
+	}
+}
+
+// devRank orders the development release segment: a version with no such segment comes after all that
+// have one.
+func (v Version) devRank() int {
+	if v.dev < 0 {
+		return maxInt
+	}
+	return v.dev
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+func compareRelease(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if d := compareInts(x, y); d != 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func compareLocal(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		n1, err1 := strconv.Atoi(a[i])
+		n2, err2 := strconv.Atoi(b[i])
+		var d int
+		switch {
+		case err1 == nil && err2 == nil:
+			d = compareInts(n1, n2)
+		case err1 == nil:
+			d = 1
+		case err2 == nil:
+			d = -1
+		case a[i] < b[i]:
+			d = -1
+		case a[i] > b[i]:
+			d = 1
+		}
+		if d != 0 {
+			return d
+		}
+	}
+	return compareInts(len(a), len(b))
+}
+
+func compareInts(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
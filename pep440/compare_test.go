@@ -0,0 +1,87 @@
+package pep440
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ semver.Comparable[Version] = Version{}
+
+// This list is in ascending order, and is based on the ordering tests in the Python "packaging" library
+// (https://github.com/pypa/packaging/blob/main/tests/test_version.py).
+var orderedVersions = []string{
+	"1.0.dev456",
+	"1.0a1",
+	"1.0a2.dev456",
+	"1.0a12.dev456",
+	"1.0a12",
+	"1.0b1.dev456",
+	"1.0b2",
+	"1.0b2.post345.dev456",
+	"1.0b2.post345",
+	"1.0b2-346",
+	"1.0c1.dev456",
+	"1.0c1",
+	"1.0rc2",
+	"1.0c3",
+	"1.0",
+	"1.0.post456.dev34",
+	"1.0.post456",
+	"1.1.dev1",
+	"1.2+123abc",
+	"1.2+123abc456",
+	"1.2+abc",
+	"1.2+abc123",
+	"1.2+abc123def",
+	"1.2+1234.abc",
+	"1.2+123456",
+	"1.2.r32+123456",
+	"1.2.rev33+123456",
+	"1!1.0b2.post345.dev456",
+	"1!1.0",
+	"1!1.0.post456",
+	"1!1.2.rev33+123456",
+}
+
+func TestCompareOrdering(t *testing.T) {
+	versions := make([]Version, len(orderedVersions))
+	for i, s := range orderedVersions {
+		v, err := Parse(s)
+		require.NoError(t, err, s)
+		versions[i] = v
+	}
+	for i := range versions {
+		for j := range versions {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(t, expected, versions[i].ComparePrecedence(versions[j]), "%s vs %s", orderedVersions[i],
+				orderedVersions[j])
+		}
+	}
+}
+
+func TestCompareEquivalentSpellings(t *testing.T) {
+	for _, pair := range [][2]string{
+		{"1.0", "1.0.0"},
+		{"1", "1.0.0.0"},
+		{"0!1.0", "1.0"},
+		{"1.0alpha1", "1.0a1"},
+		{"1.0-1", "1.0.post1"},
+		{"1.0+Ubuntu_1", "1.0+ubuntu.1"},
+		{"1.0+01", "1.0+1"},
+	} {
+		a, err := Parse(pair[0])
+		require.NoError(t, err)
+		b, err := Parse(pair[1])
+		require.NoError(t, err)
+		assert.Equal(t, 0, a.ComparePrecedence(b), "%s vs %s", pair[0], pair[1])
+	}
+}
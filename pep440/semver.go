@@ -0,0 +1,70 @@
+package pep440
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// NearestSemver returns the semantic version that most closely corresponds to this version. The second
+// return value is true if the conversion is exact, meaning that no information was lost and that
+// exactly converted versions have the same relative order in both schemes.
+//
+// The conversion is as follows:
+//
+//   - The first three release components become the major, minor, and patch versions, with missing ones
+//     treated as zero. Any further components are dropped, and the conversion is inexact unless they
+//     are all zero.
+//   - A pre-release segment becomes a prerelease component, as in "1.0rc1" to "1.0.0-rc.1".
+//   - A development release segment is appended to the prerelease component, as in "1.0.dev2" to
+//     "1.0.0-dev.2" or "1.0a1.dev2" to "1.0.0-a.1.dev.2"; this is inexact, because development releases
+//     are ordered differently in the two schemes.
+//   - A post-release segment and a local version label become build metadata, as in "1.0.post1+abc" to
+//     "1.0.0+post.1.abc"; this is inexact, because build metadata does not affect precedence.
+//   - A nonzero epoch is dropped, and the conversion is inexact.
+func (v Version) NearestSemver() (semver.Version, bool) {
+	exact := v.epoch == 0 && v.post < 0 && v.dev < 0 && len(v.local) == 0
+	var buf []byte
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			buf = append(buf, '.')
+		}
+		n := 0
+		if i < len(v.release) {
+			n = v.release[i]
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+	}
+	for _, n := range v.release[min(3, len(v.release)):] {
+		if n != 0 {
+			exact = false
+		}
+	}
+
+	var prerelease []string
+	if v.preKind != "" {
+		prerelease = append(prerelease, v.preKind, strconv.Itoa(v.preNum))
+	}
+	if v.dev >= 0 {
+		prerelease = append(prerelease, "dev", strconv.Itoa(v.dev))
+	}
+	if len(prerelease) != 0 {
+		buf = append(buf, '-')
+		buf = append(buf, strings.Join(prerelease, ".")...)
+	}
+
+	var build []string
+	if v.post >= 0 {
+		build = append(build, "post", strconv.Itoa(v.post))
+	}
+	build = append(build, v.local...)
+	if len(build) != 0 {
+		buf = append(buf, '+')
+		buf = append(buf, strings.Join(build, ".")...)
+	}
+
+	// The components above are always valid, so parsing cannot fail.
+	result, _ := semver.Parse(string(buf))
+	return result, exact
+}
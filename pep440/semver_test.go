@@ -0,0 +1,51 @@
+package pep440
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNearestSemver(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		expected string
+		exact    bool
+	}{
+		{"1", "1.0.0", true},
+		{"1.2", "1.2.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"1.2.3.0", "1.2.3", true},
+		{"1.2.3.4", "1.2.3", false},
+		{"1.0a1", "1.0.0-a.1", true},
+		{"1.0b2", "1.0.0-b.2", true},
+		{"1.0rc3", "1.0.0-rc.3", true},
+		{"1.0.dev2", "1.0.0-dev.2", false},
+		{"1.0a1.dev2", "1.0.0-a.1.dev.2", false},
+		{"1.0.post1", "1.0.0+post.1", false},
+		{"1.0+ubuntu.1", "1.0.0+ubuntu.1", false},
+		{"1.0.post1+abc", "1.0.0+post.1.abc", false},
+		{"2!1.0", "1.0.0", false},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			require.NoError(t, err)
+			sv, exact := v.NearestSemver()
+			assert.Equal(t, test.expected, sv.String())
+			assert.Equal(t, test.exact, exact)
+		})
+	}
+}
+
+func TestNearestSemverPreservesOrderWhenExact(t *testing.T) {
+	inputs := []string{"0.9", "1.0a1", "1.0a2", "1.0b1", "1.0rc1", "1.0", "1.0.1", "1.1", "2"}
+	for i := 1; i < len(inputs); i++ {
+		a, _ := Parse(inputs[i-1])
+		b, _ := Parse(inputs[i])
+		sa, exactA := a.NearestSemver()
+		sb, exactB := b.NearestSemver()
+		require.True(t, exactA && exactB)
+		assert.Equal(t, a.ComparePrecedence(b), sa.ComparePrecedence(sb), "%s vs %s", inputs[i-1], inputs[i])
+	}
+}
@@ -0,0 +1,198 @@
+package pep440
+
+import (
+	"errors"
+	"strings"
+)
+
+// SpecifierSet is a set of PEP 440 version specifiers, such as "~=1.4.2, !=1.4.5", all of which must be
+// satisfied by a matching version. It is immutable once created by ParseSpecifierSet.
+type SpecifierSet struct {
+	specifiers []specifier
+}
+
+type specifier struct {
+	op       string
+	version  Version
+	wildcard bool   // for "==" and "!=" with a trailing ".*"
+	text     string // the version text, for "==="
+}
+
+var errInvalidSpecifier = errors.New("invalid PEP 440 version specifier")
+
+// Operators are listed so that longer ones are matched first.
+var specifierOperators = []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// ParseSpecifierSet attempts to parse a comma-separated list of version specifiers. Each specifier is
+// one of the operators "~=", "==", "!=", "<=", ">=", "<", ">", or "===" followed by a version; "==" and
+// "!=" also allow a prefix match such as "==1.4.*". An empty string is a set with no specifiers, which
+// matches every version.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and SpecifierSet{} as the
+// first.
+func ParseSpecifierSet(s string) (SpecifierSet, error) {
+	var ret SpecifierSet
+	if strings.TrimSpace(s) == "" {
+		return ret, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		spec, ok := parseSpecifier(strings.TrimSpace(part))
+		if !ok {
+			return SpecifierSet{}, errInvalidSpecifier
+		}
+		ret.specifiers = append(ret.specifiers, spec)
+	}
+	return ret, nil
+}
+
+func parseSpecifier(s string) (specifier, bool) {
+	var spec specifier
+	for _, op := range specifierOperators {
+		if strings.HasPrefix(s, op) {
+			spec.op = op
+			break
+		}
+	}
+	if spec.op == "" {
+		return specifier{}, false
+	}
+	versionText := strings.TrimSpace(s[len(spec.op):])
+	if spec.op == "===" {
+		spec.text = versionText
+		return spec, versionText != ""
+	}
+	if spec.op == "==" || spec.op == "!=" {
+		versionText, spec.wildcard = strings.CutSuffix(versionText, ".*")
+	}
+	v, err := Parse(versionText)
+	if err != nil {
+		return specifier{}, false
+	}
+	if spec.wildcard && (v.preKind != "" || v.post >= 0 || v.dev >= 0 || len(v.local) != 0) {
+		return specifier{}, false
+	}
+	if len(v.local) != 0 && spec.op != "==" && spec.op != "!=" {
+		return specifier{}, false
+	}
+	if spec.op == "~=" && len(v.release) < 2 {
+		return specifier{}, false
+	}
+	spec.version = v
+	return spec, true
+}
+
+// Contains returns true if the version satisfies every specifier in the set.
+//
+// Pre-releases (including development releases) are excluded unless the prereleases parameter is true,
+// or one of the specifiers in the set other than "!=" refers to a pre-release, as recommended by PEP 440.
+func (s SpecifierSet) Contains(v Version, prereleases bool) bool {
+	if v.IsPrerelease() && !prereleases && !s.mentionsPrerelease() {
+		return false
+	}
+	for _, spec := range s.specifiers {
+		if !spec.contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the specifiers in normal form, separated by commas.
+func (s SpecifierSet) String() string {
+	parts := make([]string, 0, len(s.specifiers))
+	for _, spec := range s.specifiers {
+		switch {
+		case spec.op == "===":
+			parts = append(parts, spec.op+spec.text)
+		case spec.wildcard:
+			parts = append(parts, spec.op+spec.version.String()+".*")
+		default:
+			parts = append(parts, spec.op+spec.version.String())
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s SpecifierSet) mentionsPrerelease() bool {
+	for _, spec := range s.specifiers {
+		switch spec.op {
+		case "!=":
+			// an exclusion never opts in to pre-releases
+		case "===":
+			if v, err := Parse(spec.text); err == nil && v.IsPrerelease() {
+				return true
+			}
+		default:
+			if spec.version.IsPrerelease() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (spec specifier) contains(v Version) bool {
+	switch spec.op {
+	case "===":
+		return strings.EqualFold(v.String(), spec.text)
+	case "==":
+		return spec.equals(v)
+	case "!=":
+		return !spec.equals(v)
+	case "~=":
+		prefix := spec.version.release[:len(spec.version.release)-1]
+		return v.ComparePrecedence(spec.version) >= 0 && hasReleasePrefix(v, spec.version.epoch, prefix)
+	case "<=":
+		return v.Public().ComparePrecedence(spec.version) <= 0
+	case ">=":
+		return v.Public().ComparePrecedence(spec.version) >= 0
+	case "<":
+		// "<V" must not match a pre-release of V unless V is itself a pre-release
+		if !spec.version.IsPrerelease() && v.IsPrerelease() && sameBaseVersion(v, spec.version) {
+			return false
+		}
+		return v.Public().ComparePrecedence(spec.version) < 0
+	default: // ">"
+		// ">V" must not match a post-release of V unless V is itself a post-release, and must not
+		// match V with a local version label
+		if spec.version.post < 0 && v.post >= 0 && sameBaseVersion(v, spec.version) {
+			return false
+		}
+		if len(v.local) != 0 && sameBaseVersion(v, spec.version) {
+			return false
+		}
+		return v.Public().ComparePrecedence(spec.version) > 0
+	}
+}
+
+func (spec specifier) equals(v Version) bool {
+	if spec.wildcard {
+		return hasReleasePrefix(v, spec.version.epoch, spec.version.release)
+	}
+	if len(spec.version.local) == 0 {
+		v = v.Public()
+	}
+	return v.ComparePrecedence(spec.version) == 0
+}
+
+// hasReleasePrefix returns true if the version has the specified epoch and its release segment, padded
+// with zeroes if necessary, begins with the specified components.
+func hasReleasePrefix(v Version, epoch int, prefix []int) bool {
+	if v.epoch != epoch {
+		return false
+	}
+	for i, n := range prefix {
+		component := 0
+		if i < len(v.release) {
+			component = v.release[i]
+		}
+		if component != n {
+			return false
+		}
+	}
+	return true
+}
+
+func sameBaseVersion(a, b Version) bool {
+	return a.epoch == b.epoch && compareRelease(a.release, b.release) == 0
+}
@@ -0,0 +1,110 @@
+package pep440
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpecifierSetContains(t *testing.T) {
+	for _, test := range []struct {
+		specifiers  string
+		version     string
+		prereleases bool
+		expected    bool
+	}{
+		{"", "1.0", false, true},
+		{"", "1.0a1", false, false},
+		{"", "1.0a1", true, true},
+
+		{"==2.0", "2.0", false, true},
+		{"==2.0", "2.0.0", false, true},
+		{"==2.0", "2.0+deadbeef", false, true},
+		{"==2.0+deadbeef", "2.0+deadbeef", false, true},
+		{"==2.0+deadbeef", "2.0", false, false},
+		{"==2.0", "2.0.post1", false, false},
+		{"==2.*", "2.5.1", false, true},
+		{"==2.0.*", "2.0", false, true},
+		{"==2.0.*", "2.0.post1", false, true},
+		{"==2.0.*", "2.1", false, false},
+		{"==1!2.*", "2.0", false, false},
+		{"==2.0.*", "2.0rc1", false, false},
+		{"==2.0.*", "2.0rc1", true, true},
+		{"==2.0rc1", "2.0rc1", false, true},
+
+		{"!=2.0", "2.1", false, true},
+		{"!=2.0", "2.0.0+local", false, false},
+		{"!=2.0.*", "2.0.1", false, false},
+		{"!=2.0.*", "2.1", false, true},
+
+		{"~=1.4.2", "1.4.2", false, true},
+		{"~=1.4.2", "1.4.9", false, true},
+		{"~=1.4.2", "1.5.0", false, false},
+		{"~=1.4.2", "1.4.1", false, false},
+		{"~=1.4", "1.9", false, true},
+		{"~=1.4", "2.0", false, false},
+		{"~=2.2.post3", "2.2.post3", false, true},
+		{"~=2.2.post3", "2.3", false, true},
+		{"~=2.2.post3", "2.2", false, false},
+		{"~=1.4.5a4", "1.4.5", false, true},
+		{"~=1.4.5a4", "1.4.5b1", false, true},
+
+		{"~=1.4.2, !=1.4.5", "1.4.4", false, true},
+		{"~=1.4.2, !=1.4.5", "1.4.5", false, false},
+		{"~=1.4.2, !=1.4.5", "1.4.6", false, true},
+		{">= 1.0, < 2.0", "1.5", false, true},
+		{">=1.0,<2.0", "2.0", false, false},
+
+		{"<=2.0", "2.0", false, true},
+		{"<=2.0", "2.0+local", false, true},
+		{"<=2.0", "2.0.post1", false, false},
+		{">=2.0", "2.0", false, true},
+		{">=2.0", "1.9.post9", false, false},
+
+		{"<2.0", "1.9", false, true},
+		{"<2.0", "2.0rc1", true, false},
+		{"<2.0", "1.9rc1", true, true},
+		{"<2.0rc2", "2.0rc1", false, true},
+		{"<2.0rc2", "2.0rc1", true, true},
+
+		{">2.0", "2.1", false, true},
+		{">2.0", "2.0.post1", false, false},
+		{">2.0", "2.0+local", false, false},
+		{">2.0.post1", "2.0.post2", false, true},
+		{">2.0", "2.1.dev0", false, false},
+		{">2.0", "2.1.dev0", true, true},
+
+		{"===1.0", "1.0", false, true},
+		{"===1.0", "1.0.0", false, false},
+		{"===1.0a1", "1.0a1", false, true},
+		{">=1.0a1", "1.0a2", false, true},
+	} {
+		t.Run(test.specifiers+" contains "+test.version, func(t *testing.T) {
+			s, err := ParseSpecifierSet(test.specifiers)
+			require.NoError(t, err)
+			v, err := Parse(test.version)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, s.Contains(v, test.prereleases))
+		})
+	}
+}
+
+func TestParseSpecifierSetInvalid(t *testing.T) {
+	for _, s := range []string{
+		"1.0", "=1.0", "==", "~=1", "~=1.0+local", ">=1.0+local", "<1.*", ">=1.0.*", "==1.0a1.*",
+		"==1.0+abc.*", "===", ">=1.0,", ">=1.0,,<2", "=>1.0", "==1.0 junk",
+	} {
+		t.Run(s, func(t *testing.T) {
+			set, err := ParseSpecifierSet(s)
+			assert.Error(t, err)
+			assert.Equal(t, SpecifierSet{}, set)
+		})
+	}
+}
+
+func TestSpecifierSetString(t *testing.T) {
+	s, err := ParseSpecifierSet(" ~= 1.4.2 ,!=1.4.5,==2.0.*, ===foo ,>=1.0-alpha")
+	require.NoError(t, err)
+	assert.Equal(t, "~=1.4.2, !=1.4.5, ==2.0.*, ===foo, >=1.0a0", s.String())
+}
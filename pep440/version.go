@@ -0,0 +1,283 @@
+// Package pep440 implements the version scheme used by Python packages, as defined by PEP 440
+// (https://peps.python.org/pep-0440/), including its ordering rules and version specifiers.
+//
+// This is a different scheme from semantic versioning, with its own ordering rules: for instance,
+// "1.0.dev1" < "1.0a1" < "1.0" < "1.0.post1", and "1.0" and "1.0.0" are the same version. A Version
+// can be approximated as a semver.Version with NearestSemver.
+package pep440
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed PEP 440 version.
+type Version struct {
+	epoch   int
+	release []int
+	preKind string // "a", "b", "rc", or "" if there is no pre-release segment
+	preNum  int
+	post    int // -1 if there is no post-release segment
+	dev     int // -1 if there is no development release segment
+	local   []string
+}
+
+var errInvalidVersion = errors.New("invalid PEP 440 version")
+
+// Parse attempts to parse a string into a Version.
+//
+// Any of the alternative spellings that PEP 440 allows are accepted, and converted to normal form: the
+// string is case-insensitive and may be surrounded by whitespace; it may have a "v" prefix; "alpha",
+// "beta", "c", "pre" and "preview" mean "a", "b", "rc", "rc" and "rc"; "rev" and "r" mean "post"; the
+// separators ".", "-" and "_" may appear before and within the pre-release, post-release and
+// development release segments; a missing pre-release, post-release or development release number is
+// zero; "1.0-1" means "1.0.post1"; and numeric components may have leading zeroes.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and Version{} as the first.
+func Parse(s string) (Version, error) {
+	p := parser{s: strings.ToLower(strings.TrimSpace(s))}
+	v := Version{post: -1, dev: -1}
+
+	p.skip("v")
+	n, ok := p.number()
+	if !ok {
+		return Version{}, errInvalidVersion
+	}
+	if p.skip("!") {
+		v.epoch = n
+		if n, ok = p.number(); !ok {
+			return Version{}, errInvalidVersion
+		}
+	}
+	v.release = append(v.release, n)
+	for p.peek() == '.' && isDigit(p.peekAt(1)) {
+		p.pos++
+		n, _ = p.number()
+		v.release = append(v.release, n)
+	}
+
+	start := p.pos
+	p.separator()
+	if kind, ok := p.keyword(preReleaseSpellings); ok {
+		v.preKind = kind
+		v.preNum = p.optionalNumber()
+	} else {
+		p.pos = start
+	}
+
+	start = p.pos
+	if p.skip("-") && isDigit(p.peek()) {
+		v.post, _ = p.number()
+	} else {
+		p.pos = start
+		p.separator()
+		if _, ok := p.keyword(postReleaseSpellings); ok {
+			v.post = p.optionalNumber()
+		} else {
+			p.pos = start
+		}
+	}
+
+	start = p.pos
+	p.separator()
+	if _, ok := p.keyword(devReleaseSpellings); ok {
+		v.dev = p.optionalNumber()
+	} else {
+		p.pos = start
+	}
+
+	if p.skip("+") {
+		for {
+			segment := p.alphanumerics()
+			if segment == "" {
+				return Version{}, errInvalidVersion
+			}
+			v.local = append(v.local, segment)
+			if !p.separator() {
+				break
+			}
+		}
+	}
+
+	if !p.eof() || p.failed {
+		return Version{}, errInvalidVersion
+	}
+	return v, nil
+}
+
+// Epoch returns the epoch, which is zero if it was not specified.
+func (v Version) Epoch() int {
+	return v.epoch
+}
+
+// Release returns a copy of the numeric components of the release segment, such as [1, 2, 0] for "1.2.0".
+func (v Version) Release() []int {
+	return append([]int(nil), v.release...)
+}
+
+// Pre returns the pre-release kind ("a", "b", or "rc") and number. The third return value is false if the
+// version has no pre-release segment.
+func (v Version) Pre() (string, int, bool) {
+	return v.preKind, v.preNum, v.preKind != ""
+}
+
+// Post returns the post-release number. The second return value is false if the version has no
+// post-release segment.
+func (v Version) Post() (int, bool) {
+	return v.post, v.post >= 0
+}
+
+// Dev returns the development release number. The second return value is false if the version has no
+// development release segment.
+func (v Version) Dev() (int, bool) {
+	return v.dev, v.dev >= 0
+}
+
+// Local returns the local version label in normal form, such as "ubuntu.1", or "" if there is none.
+func (v Version) Local() string {
+	return strings.Join(v.local, ".")
+}
+
+// IsPrerelease returns true if the version has a pre-release or development release segment.
+func (v Version) IsPrerelease() bool {
+	return v.preKind != "" || v.dev >= 0
+}
+
+// Public returns the version without its local version label.
+func (v Version) Public() Version {
+	v.local = nil
+	return v
+}
+
+// String returns the version in normal form, such as "1!2.0rc1.post2.dev3+ubuntu.1".
+func (v Version) String() string {
+	var buf []byte
+	if v.epoch != 0 {
+		buf = strconv.AppendInt(buf, int64(v.epoch), 10)
+		buf = append(buf, '!')
+	}
+	for i, n := range v.release {
+		if i > 0 {
+			buf = append(buf, '.')
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+	}
+	if v.preKind != "" {
+		buf = append(buf, v.preKind...)
+		buf = strconv.AppendInt(buf, int64(v.preNum), 10)
+	}
+	if v.post >= 0 {
+		buf = append(buf, ".post"...)
+		buf = strconv.AppendInt(buf, int64(v.post), 10)
+	}
+	if v.dev >= 0 {
+		buf = append(buf, ".dev"...)
+		buf = strconv.AppendInt(buf, int64(v.dev), 10)
+	}
+	if len(v.local) != 0 {
+		buf = append(buf, '+')
+		buf = append(buf, v.Local()...)
+	}
+	return string(buf)
+}
+
+type spelling struct {
+	text, normal string
+}
+
+// Longer spellings come first so that, for instance, "rc" is not matched as "r".
+var (
+	preReleaseSpellings = []spelling{{"preview", "rc"}, {"alpha", "a"}, {"beta", "b"}, {"pre", "rc"},
+		{"rc", "rc"}, {"a", "a"}, {"b", "b"}, {"c", "rc"}}
+	postReleaseSpellings = []spelling{{"post", "post"}, {"rev", "post"}, {"r", "post"}}
+	devReleaseSpellings  = []spelling{{"dev", "dev"}}
+)
+
+// parser is a minimal cursor over a lowercase ASCII string.
+type parser struct {
+	s      string
+	pos    int
+	failed bool
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *parser) peek() byte {
+	return p.peekAt(0)
+}
+
+func (p *parser) peekAt(offset int) byte {
+	if p.pos+offset >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos+offset]
+}
+
+func (p *parser) skip(prefix string) bool {
+	if strings.HasPrefix(p.s[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *parser) separator() bool {
+	if ch := p.peek(); ch == '.' || ch == '-' || ch == '_' {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) keyword(spellings []spelling) (string, bool) {
+	for _, sp := range spellings {
+		if p.skip(sp.text) {
+			return sp.normal, true
+		}
+	}
+	return "", false
+}
+
+// number reads a run of digits. Leading zeroes are allowed, and very long runs are rejected rather
+// than overflowing.
+func (p *parser) number() (int, bool) {
+	start := p.pos
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, false
+	}
+	n, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		p.failed = true
+		return 0, false
+	}
+	return n, true
+}
+
+// optionalNumber reads a number that may be preceded by a separator, or returns 0 if there is none.
+func (p *parser) optionalNumber() int {
+	start := p.pos
+	p.separator()
+	if n, ok := p.number(); ok || p.failed {
+		return n
+	}
+	p.pos = start
+	return 0
+}
+
+func (p *parser) alphanumerics() string {
+	start := p.pos
+	for ch := p.peek(); isDigit(ch) || (ch >= 'a' && ch <= 'z'); ch = p.peek() {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
@@ -0,0 +1,99 @@
+package pep440
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNormalization(t *testing.T) {
+	for input, expected := range map[string]string{
+		"1":                  "1",
+		"1.0":                "1.0",
+		"01.002.0003":        "1.2.3",
+		"v1.0":               "1.0",
+		"  V1.0\n":           "1.0",
+		"1!2.0":              "1!2.0",
+		"0!1.0":              "1.0",
+		"1.0a1":              "1.0a1",
+		"1.0.a1":             "1.0a1",
+		"1.0-alpha_1":        "1.0a1",
+		"1.0ALPHA1":          "1.0a1",
+		"1.0beta":            "1.0b0",
+		"1.0c1":              "1.0rc1",
+		"1.0pre1":            "1.0rc1",
+		"1.0-preview.2":      "1.0rc2",
+		"1.0.rc.3":           "1.0rc3",
+		"1.0.post1":          "1.0.post1",
+		"1.0post":            "1.0.post0",
+		"1.0-r4":             "1.0.post4",
+		"1.0_rev5":           "1.0.post5",
+		"1.0-1":              "1.0.post1",
+		"1.0.dev1":           "1.0.dev1",
+		"1.0dev":             "1.0.dev0",
+		"1.0-dev_2":          "1.0.dev2",
+		"1.0a1-1":            "1.0a1.post1",
+		"1.0rc1.post2.dev3":  "1.0rc1.post2.dev3",
+		"1.0+ubuntu-1_2.X":   "1.0+ubuntu.1.2.x",
+		"2!1.0b2.post345+a1": "2!1.0b2.post345+a1",
+	} {
+		t.Run(input, func(t *testing.T) {
+			v, err := Parse(input)
+			require.NoError(t, err)
+			assert.Equal(t, expected, v.String())
+		})
+	}
+}
+
+func TestParseComponents(t *testing.T) {
+	v, err := Parse("3!1.2.3.4rc5.post6.dev7+local.8")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v.Epoch())
+	assert.Equal(t, []int{1, 2, 3, 4}, v.Release())
+	kind, n, ok := v.Pre()
+	assert.True(t, ok)
+	assert.Equal(t, "rc", kind)
+	assert.Equal(t, 5, n)
+	post, ok := v.Post()
+	assert.True(t, ok)
+	assert.Equal(t, 6, post)
+	dev, ok := v.Dev()
+	assert.True(t, ok)
+	assert.Equal(t, 7, dev)
+	assert.Equal(t, "local.8", v.Local())
+	assert.True(t, v.IsPrerelease())
+	assert.Equal(t, "3!1.2.3.4rc5.post6.dev7", v.Public().String())
+
+	plain, err := Parse("1.0")
+	require.NoError(t, err)
+	_, _, ok = plain.Pre()
+	assert.False(t, ok)
+	_, ok = plain.Post()
+	assert.False(t, ok)
+	_, ok = plain.Dev()
+	assert.False(t, ok)
+	assert.False(t, plain.IsPrerelease())
+
+	devOnly, err := Parse("1.0.dev0")
+	require.NoError(t, err)
+	assert.True(t, devOnly.IsPrerelease())
+
+	release := v.Release()
+	release[0] = 99
+	assert.Equal(t, 1, v.Release()[0], "Release returns a copy")
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", "v", "a1", "1.", "1..0", "1.0.", "!1.0", "1!", "1.0a1a2", "1.0+", "1.0+a..b", "1.0+a+b",
+		"1.0-", "1.0.post-", "1.0 beta", "1.0.*", "1.0🔥", "1.0x1", "99999999999999999999999.0",
+		"1.0a99999999999999999999999", "1.0-1a1",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := Parse(s)
+			assert.Error(t, err)
+			assert.Equal(t, Version{}, v)
+		})
+	}
+}
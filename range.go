@@ -0,0 +1,296 @@
+package semver
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// Range is a set of versions described by a range expression such as ">=1.2.0 <2.0.0" or "^1.2 || 3.x".
+// It is immutable once created by ParseRange.
+//
+// The syntax is that of npm's node-semver package:
+//
+//   - A range is one or more comparator sets separated by "||"; a version is in the range if it satisfies
+//     every comparator in any one of the sets.
+//   - Comparators within a set are separated by whitespace or commas. Each is an operator ("=", "<", "<=",
+//     ">", ">=", "~", or "^"; no operator means "=") followed by a version, which may have a "v" prefix.
+//   - A version may be partial ("1.2") or use "x", "X" or "*" as a wildcard ("1.2.x", "1.*"), in which case
+//     it stands for every version whose numeric components begin with the ones given, including prereleases.
+//     So "1.2" is equivalent to ">=1.2.0-0 <1.3.0-0", where "-0" denotes the lowest possible prerelease.
+//   - "~1.2.3" allows patch-level changes (">=1.2.3 <1.3.0-0") and "^1.2.3" allows changes that do not modify
+//     the leftmost nonzero component (">=1.2.3 <2.0.0-0", or ">=0.2.3 <0.3.0-0" for "^0.2.3").
+//   - "A - B" is a hyphen range, equivalent to ">=A <=B" if B is a complete version.
+//
+// Unlike node-semver, versions are compared purely by precedence: there is no special rule for excluding
+// prerelease versions. This means that ">=1.0.0 <2.0.0" includes "1.5.0-beta" and also "2.0.0-beta",
+// which has lower precedence than "2.0.0"; "^1.0.0" or "<2.0.0-0" can be used to exclude the latter.
+type Range struct {
+	sets [][]comparator
+}
+
+type comparatorOp int8
+
+const (
+	opEQ comparatorOp = iota
+	opLT
+	opLE
+	opGT
+	opGE
+)
+
+var errInvalidRange = errors.New("invalid version range")
+
+// lowestPrerelease is the prerelease identifier with the lowest possible precedence, so that X.Y.Z-0
+// is lower than any other version with the same major, minor and patch components.
+const lowestPrerelease = "0"
+
+// ParseRange attempts to parse a range expression; see Range for the syntax. An empty string, like "*",
+// matches every version.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and Range{} as the first.
+func ParseRange(s string) (Range, error) {
+	var r Range
+	for _, setText := range strings.Split(s, "||") {
+		set, ok := parseComparatorSet(setText)
+		if !ok {
+			return Range{}, errInvalidRange
+		}
+		r.sets = append(r.sets, set)
+	}
+	return r, nil
+}
+
+// Contains returns true if the version is in the range.
+//
+// Each comparator is evaluated with ComparePrecedence. If the same range is used many times, Compile
+// produces a faster equivalent.
+func (r Range) Contains(v Version) bool {
+	return setsContain(r.sets, v)
+}
+
+// String returns the range in a normalized form in which every comparator uses one of the operators
+// "=", "<", "<=", ">", or ">=" with a complete version, such as ">=1.2.0-0 <1.3.0-0 || =2.0.0". A set
+// that matches every version is written as "*".
+func (r Range) String() string {
+	return formatSets(r.sets)
+}
+
+func (op comparatorOp) String() string {
+	switch op {
+	case opLT:
+		return "<"
+	case opLE:
+		return "<="
+	case opGT:
+		return ">"
+	case opGE:
+		return ">="
+	default:
+		return "="
+	}
+}
+
+func parseComparatorSet(s string) ([]comparator, bool) {
+	tokens := strings.FieldsFunc(s, func(ch rune) bool { return ch == ' ' || ch == '\t' || ch == ',' })
+
+	// an operator may be separated from its version by whitespace, as in ">= 1.2.3"
+	for i := 0; i < len(tokens)-1; i++ {
+		if strings.Trim(tokens[i], "<>=~^") == "" {
+			tokens[i] += tokens[i+1]
+			tokens = append(tokens[:i+1], tokens[i+2:]...)
+		}
+	}
+
+	set := []comparator{}
+	for i := 0; i < len(tokens); i++ {
+		if i+2 < len(tokens) && tokens[i+1] == "-" {
+			from, ok1 := parsePartialVersion(tokens[i])
+			to, ok2 := parsePartialVersion(tokens[i+2])
+			if !ok1 || !ok2 {
+				return nil, false
+			}
+			set = append(set, from.lowerBound(opGE)...)
+			set = append(set, to.upperBound(opLE)...)
+			i += 2
+			continue
+		}
+		comparators, ok := parseComparator(tokens[i])
+		if !ok {
+			return nil, false
+		}
+		set = append(set, comparators...)
+	}
+	return set, true
+}
+
+func parseComparator(s string) ([]comparator, bool) {
+	var opText string
+	for _, prefix := range []string{"<=", ">=", "~>", "==", "<", ">", "=", "~", "^"} {
+		if strings.HasPrefix(s, prefix) {
+			opText, s = prefix, s[len(prefix):]
+			break
+		}
+	}
+	p, ok := parsePartialVersion(s)
+	if !ok || (opText != "" && s == "") {
+		return nil, false
+	}
+	switch opText {
+	case "<":
+		return p.lowerBound(opLT), true
+	case "<=":
+		return p.upperBound(opLE), true
+	case ">":
+		return p.upperBound(opGT), true
+	case ">=":
+		return p.lowerBound(opGE), true
+	case "~", "~>":
+		if p.components == 3 {
+			return append([]comparator{{opGE, p.version}}, p.below(2)...), true
+		}
+		return p.prefixRange(), true
+	case "^":
+		if p.components == 0 {
+			return nil, true
+		}
+		// the upper bound increments the leftmost nonzero component of those that were specified
+		significant := 1
+		if p.version.major == 0 && p.components >= 2 {
+			significant = 2
+			if p.version.minor == 0 && p.components == 3 {
+				significant = 3
+			}
+		}
+		return append(p.lowerBound(opGE), p.below(significant)...), true
+	default:
+		if p.components == 3 {
+			return []comparator{{opEQ, p.version}}, true
+		}
+		return p.prefixRange(), true
+	}
+}
+
+// partialVersion is a version in which only the first 0-3 numeric components were specified.
+type partialVersion struct {
+	version    Version
+	components int
+}
+
+func parsePartialVersion(s string) (partialVersion, bool) {
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		s = s[1:]
+	}
+	if v, err := Parse(s); err == nil {
+		return partialVersion{version: v, components: 3}, true
+	}
+	var p partialVersion
+	if s == "" {
+		return p, true
+	}
+	for i, part := range strings.Split(s, ".") {
+		if i >= 3 {
+			return partialVersion{}, false
+		}
+		if part == "x" || part == "X" || part == "*" {
+			continue
+		}
+		n, ok := parsePositiveNumericString(part)
+		if !ok || p.components != i { // a number cannot follow a wildcard
+			return partialVersion{}, false
+		}
+		switch i {
+		case 0:
+			p.version.major = n
+		case 1:
+			p.version.minor = n
+		default:
+			p.version.patch = n
+		}
+		p.components++
+	}
+	return p, true
+}
+
+// nextAfter returns the lowest version that is higher than every version whose first n numeric
+// components match p. If a component that would be incremented is already math.MaxInt, the one before it
+// is incremented instead, as in successor; it returns false if there is no higher version at all.
+func (p partialVersion) nextAfter(n int) (Version, bool) {
+	v := p.version
+	switch {
+	case n >= 3 && v.patch < math.MaxInt:
+		return Version{major: v.major, minor: v.minor, patch: v.patch + 1, prerelease: lowestPrerelease}, true
+	case n >= 2 && v.minor < math.MaxInt:
+		return Version{major: v.major, minor: v.minor + 1, prerelease: lowestPrerelease}, true
+	case v.major < math.MaxInt:
+		return Version{major: v.major + 1, prerelease: lowestPrerelease}, true
+	default:
+		return Version{}, false
+	}
+}
+
+// below returns a comparator matching every version that is lower than nextAfter(n), or no comparator if
+// there is no such upper limit.
+func (p partialVersion) below(n int) []comparator {
+	if next, ok := p.nextAfter(n); ok {
+		return []comparator{{opLT, next}}
+	}
+	return nil
+}
+
+// above returns a comparator matching every version that is higher than those whose first n numeric
+// components match p, or one that matches nothing if there is no such version.
+func (p partialVersion) above(n int) []comparator {
+	if next, ok := p.nextAfter(n); ok {
+		return []comparator{{opGE, next}}
+	}
+	return matchNothing()
+}
+
+// matchNothing returns a comparator that no version satisfies.
+func matchNothing() []comparator {
+	return []comparator{{opLT, Version{prerelease: lowestPrerelease}}}
+}
+
+// first returns the lowest version whose numeric components begin with those of p.
+func (p partialVersion) first() Version {
+	if p.components == 3 {
+		return p.version
+	}
+	return Version{major: p.version.major, minor: p.version.minor, prerelease: lowestPrerelease}
+}
+
+// prefixRange returns comparators matching every version whose numeric components begin with those of p.
+func (p partialVersion) prefixRange() []comparator {
+	if p.components == 0 {
+		return nil
+	}
+	return append([]comparator{{opGE, p.first()}}, p.below(p.components)...)
+}
+
+// lowerBound translates "<p" or ">=p", which are both relative to the lowest version matching p.
+func (p partialVersion) lowerBound(op comparatorOp) []comparator {
+	if p.components == 0 {
+		if op == opLT {
+			return matchNothing()
+		}
+		return nil
+	}
+	return []comparator{{op, p.first()}}
+}
+
+// upperBound translates "<=p" or ">p", which are both relative to the highest version matching p.
+func (p partialVersion) upperBound(op comparatorOp) []comparator {
+	switch {
+	case p.components == 0 && op == opGT:
+		return matchNothing()
+	case p.components == 0:
+		return nil
+	case p.components == 3:
+		return []comparator{{op, p.version}}
+	case op == opGT:
+		return p.above(p.components)
+	default:
+		return p.below(p.components)
+	}
+}
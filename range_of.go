@@ -0,0 +1,134 @@
+package semver
+
+import "strings"
+
+// Set is implemented by any set of versions that can be tested for membership, such as Range and RangeOf.
+type Set[T any] interface {
+	Contains(v T) bool
+}
+
+// Constraint is a single comparison against a version, such as ">=1.2.0". It is one of the building
+// blocks of a RangeOf, and is created with Exactly, LessThan, AtMost, GreaterThan, or AtLeast.
+type Constraint[T Comparable[T]] struct {
+	op      comparatorOp
+	version T
+}
+
+// RangeOf is a set of versions of any Comparable type, described by constraints: a version is in the
+// range if it satisfies every constraint in any one of its constraint sets. It is the generic equivalent
+// of Range, for versioning schemes that do not have a range syntax of their own.
+//
+// The zero value of RangeOf matches no versions.
+type RangeOf[T Comparable[T]] struct {
+	sets [][]Constraint[T]
+}
+
+// comparator is the Constraint type used by Range.
+type comparator = Constraint[Version]
+
+// Exactly returns a Constraint that is satisfied by any version with the same precedence as v.
+func Exactly[T Comparable[T]](v T) Constraint[T] {
+	return Constraint[T]{opEQ, v}
+}
+
+// LessThan returns a Constraint that is satisfied by any version with lower precedence than v.
+func LessThan[T Comparable[T]](v T) Constraint[T] {
+	return Constraint[T]{opLT, v}
+}
+
+// AtMost returns a Constraint that is satisfied by any version with the same or lower precedence than v.
+func AtMost[T Comparable[T]](v T) Constraint[T] {
+	return Constraint[T]{opLE, v}
+}
+
+// GreaterThan returns a Constraint that is satisfied by any version with higher precedence than v.
+func GreaterThan[T Comparable[T]](v T) Constraint[T] {
+	return Constraint[T]{opGT, v}
+}
+
+// AtLeast returns a Constraint that is satisfied by any version with the same or higher precedence than v.
+func AtLeast[T Comparable[T]](v T) Constraint[T] {
+	return Constraint[T]{opGE, v}
+}
+
+// Allows returns true if the version satisfies the constraint.
+func (c Constraint[T]) Allows(v T) bool {
+	d := v.ComparePrecedence(c.version)
+	switch c.op {
+	case opLT:
+		return d < 0
+	case opLE:
+		return d <= 0
+	case opGT:
+		return d > 0
+	case opGE:
+		return d >= 0
+	default:
+		return d == 0
+	}
+}
+
+// String returns the constraint as an operator followed by a version, such as ">=1.2.0".
+func (c Constraint[T]) String() string {
+	return c.op.String() + c.version.String()
+}
+
+// NewRangeOf creates a RangeOf from one or more constraint sets. An empty set matches every version.
+//
+// For instance, NewRangeOf([]Constraint[T]{AtLeast(a), LessThan(b)}, []Constraint[T]{Exactly(c)}) is
+// equivalent to the range expression ">=a <b || =c".
+func NewRangeOf[T Comparable[T]](sets ...[]Constraint[T]) RangeOf[T] {
+	r := RangeOf[T]{sets: make([][]Constraint[T], 0, len(sets))}
+	for _, set := range sets {
+		r.sets = append(r.sets, append([]Constraint[T](nil), set...))
+	}
+	return r
+}
+
+// Contains returns true if the version is in the range.
+func (r RangeOf[T]) Contains(v T) bool {
+	return setsContain(r.sets, v)
+}
+
+// String returns the range in the same normalized form as Range.String, such as ">=1.2 <2.0 || =3.0".
+func (r RangeOf[T]) String() string {
+	return formatSets(r.sets)
+}
+
+func setsContain[T Comparable[T]](sets [][]Constraint[T], v T) bool {
+	for _, set := range sets {
+		if setContains(set, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func setContains[T Comparable[T]](set []Constraint[T], v T) bool {
+	for _, c := range set {
+		if !c.Allows(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatSets[T Comparable[T]](sets [][]Constraint[T]) string {
+	var sb strings.Builder
+	for i, set := range sets {
+		if i > 0 {
+			sb.WriteString(" || ")
+		}
+		if len(set) == 0 {
+			sb.WriteByte('*')
+		}
+		for j, c := range set {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(c.op.String())
+			sb.WriteString(c.version.String())
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,99 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, s string) Version {
+	v, err := Parse(s)
+	require.NoError(t, err)
+	return v
+}
+
+func mustParseExtended(t *testing.T, s string) ExtendedVersion {
+	v, err := ParseExtended(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestConstraintAllows(t *testing.T) {
+	v := mustParse(t, "1.2.3")
+	for _, test := range []struct {
+		constraint Constraint[Version]
+		version    string
+		expected   bool
+	}{
+		{Exactly(v), "1.2.3", true},
+		{Exactly(v), "1.2.3+build", true},
+		{Exactly(v), "1.2.4", false},
+		{LessThan(v), "1.2.2", true},
+		{LessThan(v), "1.2.3-beta", true},
+		{LessThan(v), "1.2.3", false},
+		{AtMost(v), "1.2.3", true},
+		{AtMost(v), "1.2.4", false},
+		{GreaterThan(v), "1.2.4", true},
+		{GreaterThan(v), "1.2.3", false},
+		{AtLeast(v), "1.2.3", true},
+		{AtLeast(v), "1.2.3-beta", false},
+	} {
+		t.Run(test.constraint.String()+" allows "+test.version, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.constraint.Allows(mustParse(t, test.version)))
+		})
+	}
+}
+
+func TestRangeOf(t *testing.T) {
+	// ExtendedVersion stands in for any versioning scheme other than Version
+	r := NewRangeOf(
+		[]Constraint[ExtendedVersion]{AtLeast(mustParseExtended(t, "1.2")), LessThan(mustParseExtended(t, "1.3"))},
+		[]Constraint[ExtendedVersion]{Exactly(mustParseExtended(t, "2.0.0.1"))},
+	)
+	assert.Equal(t, ">=1.2 <1.3 || =2.0.0.1", r.String())
+	for version, expected := range map[string]bool{
+		"1.2":        true,
+		"1.2.0.0.1":  true,
+		"1.2.9.9.9":  true,
+		"1.3":        false,
+		"1.1.9":      false,
+		"2.0.0.1":    true,
+		"2.0.0.1.0":  true,
+		"2.0.0.1.1":  false,
+		"1.3.0-beta": true,
+	} {
+		assert.Equal(t, expected, r.Contains(mustParseExtended(t, version)), version)
+	}
+}
+
+func TestRangeOfSpecialCases(t *testing.T) {
+	assert.False(t, RangeOf[Version]{}.Contains(mustParse(t, "1.0.0")))
+	assert.Equal(t, "", RangeOf[Version]{}.String())
+
+	everything := NewRangeOf([]Constraint[Version]{})
+	assert.True(t, everything.Contains(mustParse(t, "1.0.0")))
+	assert.Equal(t, "*", everything.String())
+
+	set := []Constraint[Version]{AtLeast(mustParse(t, "1.0.0"))}
+	r := NewRangeOf(set)
+	set[0] = AtLeast(mustParse(t, "2.0.0"))
+	assert.True(t, r.Contains(mustParse(t, "1.5.0")), "NewRangeOf copies its parameters")
+}
+
+func TestRangeOfMatchesRange(t *testing.T) {
+	r, err := ParseRange(">=1.2.0 <1.3.0-0 || 2.0.0")
+	require.NoError(t, err)
+	ro := NewRangeOf(
+		[]Constraint[Version]{AtLeast(mustParse(t, "1.2.0")), LessThan(mustParse(t, "1.3.0-0"))},
+		[]Constraint[Version]{Exactly(mustParse(t, "2.0.0"))},
+	)
+	assert.Equal(t, r.String(), ro.String())
+	for _, test := range rangeTests {
+		v, err := Parse(trimV(test.version))
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, r.Contains(v), ro.Contains(v), test.version)
+	}
+}
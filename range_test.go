@@ -0,0 +1,222 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rangeTest struct {
+	rangeString string
+	version     string
+	expected    bool
+}
+
+// Most of these cases are adapted from the range tests in github.com/npm/node-semver, except where our
+// purely precedence-based treatment of prerelease versions gives a different answer.
+var rangeTests = []rangeTest{
+	{"1.0.0 - 2.0.0", "1.2.3", true},
+	{"1.0.0 - 2.0.0", "2.0.0", true},
+	{"1.0.0 - 2.0.0", "2.0.1", false},
+	{"1.0.0 - 2.0.0", "0.9.9", false},
+	{"1.2.3 - 2.3", "2.3.9", true},
+	{"1.2.3 - 2.3", "2.4.0-0", false},
+	{"1.2 - 2", "1.2.0-beta", true},
+	{"1.2 - 2", "2.9.9", true},
+	{"1.2 - 2", "3.0.0-0", false},
+	{"1.0.0", "1.0.0", true},
+	{"1.0.0", "1.0.0+build", true},
+	{"=1.0.0", "1.0.1", false},
+	{"==v1.0.0", "1.0.0", true},
+	{">=*", "0.2.4", true},
+	{"", "1.0.0", true},
+	{"*", "1.2.3-foo", true},
+	{">1.0.0", "1.0.1", true},
+	{">1.0.0", "1.0.0", false},
+	{">1.0.0", "1.0.1-0", true},
+	{">= 1.0.0", "1.0.0", true},
+	{">=1.0.0", "0.9.9", false},
+	{"<2.0.0", "1.9999.9999", true},
+	{"<2.0.0", "2.0.0-beta", true},
+	{"<2.0.0-0", "2.0.0-beta", false},
+	{"<=2.0.0", "2.0.0", true},
+	{"<=2.0.0", "2.0.1", false},
+	{"<\t2.0.0", "0.2.9", true},
+	{">=0.1.97", "v0.1.97", true},
+	{"0.1.20 || 1.2.4", "1.2.4", true},
+	{"0.1.20 || 1.2.4", "1.2.3", false},
+	{">=0.2.3 || <0.0.1", "0.0.0", true},
+	{">=0.2.3 || <0.0.1", "0.2.2", false},
+	{"2.x.x", "2.1.3", true},
+	{"2.x.x", "3.1.3", false},
+	{"1.2.x", "1.2.3", true},
+	{"1.2.x", "1.3.3", false},
+	{"1.2.x || 2.x", "2.1.3", true},
+	{"x", "1.2.3", true},
+	{"2.*.*", "2.1.3", true},
+	{"1.2.*", "1.2.3", true},
+	{"2", "2.1.2", true},
+	{"2", "2.0.0-alpha", true},
+	{"2", "1.9.9", false},
+	{"2.3", "2.3.1", true},
+	{"2.3", "2.4.1", false},
+	{"~0.0.1", "0.0.1", true},
+	{"~0.0.1", "0.0.2", true},
+	{"~0.0.1", "0.1.0", false},
+	{"~2.4", "2.4.0", true},
+	{"~2.4", "2.4.5", true},
+	{"~2.4", "2.5.0", false},
+	{"~>3.2.1", "3.2.2", true},
+	{"~1", "1.2.3", true},
+	{"~1", "2.0.0-0", false},
+	{"~>1", "1.2.3", true},
+	{"~ 1.0", "1.0.2", true},
+	{"~1.0.3", "1.0.12", true},
+	{"~1.2.1 >=1.2.3", "1.2.3", true},
+	{"~1.2.1 >=1.2.3", "1.2.2", false},
+	{"~1.2.1 =1.2.3", "1.2.3", true},
+	{"~1.2.1 1.2.3", "1.2.3", true},
+	{">=1.2.1 1.2.3", "1.2.3", true},
+	{"1.2.3 >=1.2.1", "1.2.3", true},
+	{">=1.2.3 >=1.2.1", "1.2.3", true},
+	{">=1.2.1 >=1.2.3", "1.2.3", true},
+	{">=1.2", "1.2.8", true},
+	{">=1.2", "1.2.0-beta", true},
+	{">=1.2", "1.1.9", false},
+	{">1.2", "1.3.0-0", true},
+	{">1.2", "1.2.9", false},
+	{"<1.2", "1.1.1", true},
+	{"<1.2", "1.2.0-0", false},
+	{"<=1.2", "1.2.9", true},
+	{"<=1.2", "1.3.0-0", false},
+	{"^1.2.3", "1.8.1", true},
+	{"^1.2.3", "1.2.3-beta", false},
+	{"^1.2.3", "2.0.0-0", false},
+	{"^0.1.2", "0.1.2", true},
+	{"^0.1.2", "0.2.0", false},
+	{"^0.1", "0.1.2", true},
+	{"^0.0.1", "0.0.1", true},
+	{"^0.0.1", "0.0.2", false},
+	{"^0.0", "0.0.9", true},
+	{"^0.0", "0.1.0", false},
+	{"^0", "0.9.9", true},
+	{"^0", "1.0.0", false},
+	{"^1.2", "1.4.2", true},
+	{"^1.2 ^1", "1.4.2", true},
+	{"^1.2.3-alpha", "1.2.3-pre", true},
+	{"^1.2.0-alpha", "1.2.0-pre", true},
+	{"^0.0.1-alpha", "0.0.1-beta", true},
+	{"^0.0.1-alpha", "0.0.1", true},
+	{"^0.1.1-alpha", "0.1.1-beta", true},
+	{"^x", "1.2.3", true},
+	{"1.0.0, <2", "1.0.0", true},
+	{">=1.0.0,<2.0.0", "1.5.0", true},
+	{">=1.0.0,<2.0.0", "2.0.0", false},
+	{"<*", "0.0.0-0", false},
+	{">*", "1.0.0", false},
+	{">=1.0.0 <1.0.0", "1.0.0", false},
+	{">1.0.0 <=1.0.0", "1.0.0", false},
+	{"<0.0.0-0", "0.0.0-0", false},
+	{"<=0.0.0-0", "0.0.0-0", true},
+	{"1.0.0 - 2.0.0 || >=3.0.0", "3.0.0", true},
+	{"1.0.0 - 2.0.0 || >=3.0.0", "2.5.0", false},
+	{"<=*", "1.0.0", true},
+	{"<=9223372036854775807", "1.0.0", true},
+	{"<=9223372036854775807", "9223372036854775807.9223372036854775807.9223372036854775807", true},
+	{">9223372036854775807", "9223372036854775807.9223372036854775807.9223372036854775807", false},
+	{"^9223372036854775807.0.0", "9223372036854775807.1.0", true},
+	{"^9223372036854775807.0.0", "9223372036854775806.1.0", false},
+	{"~1.9223372036854775807.0", "1.9223372036854775807.5", true},
+	{"~1.9223372036854775807.0", "2.0.0-0", false},
+	{"1.2.9223372036854775807", "1.2.9223372036854775807", true},
+	{"<=1.2", "1.2.9223372036854775807", true},
+	{"^0.0.9223372036854775807", "0.1.0-0", false},
+}
+
+func TestRangeContains(t *testing.T) {
+	for _, test := range rangeTests {
+		t.Run(test.rangeString+" contains "+test.version, func(t *testing.T) {
+			r, err := ParseRange(test.rangeString)
+			require.NoError(t, err)
+			v, err := ParseAs(trimV(test.version), ParseModeStrict)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, r.Contains(v))
+		})
+	}
+}
+
+func trimV(s string) string {
+	if s != "" && s[0] == 'v' {
+		return s[1:]
+	}
+	return s
+}
+
+func TestRangeString(t *testing.T) {
+	for input, expected := range map[string]string{
+		"":                    "*",
+		"*":                   "*",
+		"x || 1.x":            "* || >=1.0.0-0 <2.0.0-0",
+		"1.2.3":               "=1.2.3",
+		"v1.2.3-beta+b":       "=1.2.3-beta+b",
+		"1.2":                 ">=1.2.0-0 <1.3.0-0",
+		"~1.2.3":              ">=1.2.3 <1.3.0-0",
+		"^0.0.3":              ">=0.0.3 <0.0.4-0",
+		"^1.2":                ">=1.2.0-0 <2.0.0-0",
+		"1 - 2.3.4":           ">=1.0.0-0 <=2.3.4",
+		">1.2.3, <=2":         ">1.2.3 <3.0.0-0",
+		">1":                  ">=2.0.0-0",
+		"<*":                  "<0.0.0-0",
+		">=1.0.0 || <0.1.0-0": ">=1.0.0 || <0.1.0-0",
+		"<=*":                 "*",
+
+		"<=9223372036854775807":    "*",
+		">9223372036854775807":     "<0.0.0-0",
+		"^9223372036854775807.0.0": ">=9223372036854775807.0.0",
+		"1.9223372036854775807":    ">=1.9223372036854775807.0-0 <2.0.0-0",
+		"~1.2.9223372036854775807": ">=1.2.9223372036854775807 <1.3.0-0",
+		"^0.0.9223372036854775807": ">=0.0.9223372036854775807 <0.1.0-0",
+		"9223372036854775807.x":    ">=9223372036854775807.0.0-0",
+	} {
+		t.Run(input, func(t *testing.T) {
+			r, err := ParseRange(input)
+			require.NoError(t, err)
+			assert.Equal(t, expected, r.String())
+		})
+	}
+}
+
+func TestParseRangeInvalid(t *testing.T) {
+	for _, s := range []string{
+		"blerg",
+		"1.2.3.4",
+		">=",
+		">= ",
+		"1.2.3 -",
+		"- 1.2.3",
+		"1.x.3",
+		"*.2",
+		"1.2-beta",
+		"1.x-beta",
+		"01.2.3",
+		">=1.2.3 || junk",
+		"=>1.2.3",
+		"<>1.2.3",
+		"^~1.2.3",
+		"1.2.3 - foo",
+		"foo - 1.2.3",
+		"1.2.🔥",
+	} {
+		t.Run(s, func(t *testing.T) {
+			r, err := ParseRange(s)
+			assert.Error(t, err)
+			assert.Equal(t, Range{}, r)
+		})
+	}
+}
+
+func TestZeroRangeContainsNothing(t *testing.T) {
+	assert.False(t, Range{}.Contains(Version{}))
+	assert.Equal(t, "", Range{}.String())
+}
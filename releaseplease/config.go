@@ -0,0 +1,258 @@
+package releaseplease
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DefaultConfigFile is the file name that release-please uses for the configuration by default.
+const DefaultConfigFile = "release-please-config.json"
+
+// Config is the content of a release-please configuration file.
+//
+// Only the options that affect version calculation are represented as fields; all other options are
+// kept in PackageConfig.Other so that reading and then writing a file does not lose anything.
+type Config struct {
+	// Defaults holds the top-level options, which apply to every package unless overridden.
+	Defaults PackageConfig
+
+	// Packages maps each package path to its package-specific options.
+	Packages map[string]PackageConfig
+}
+
+// PackageConfig is the set of options for one package, or the top-level defaults.
+type PackageConfig struct {
+	ReleaseType               string
+	Versioning                string
+	PackageName               string
+	Component                 string
+	BootstrapSHA              string
+	BumpMinorPreMajor         *bool
+	BumpPatchForMinorPreMajor *bool
+
+	// Other holds any options that are not represented by the fields above, in their original JSON form.
+	Other map[string]json.RawMessage
+}
+
+type packageConfigFields struct {
+	ReleaseType               string `json:"release-type,omitempty"`
+	Versioning                string `json:"versioning,omitempty"`
+	PackageName               string `json:"package-name,omitempty"`
+	Component                 string `json:"component,omitempty"`
+	BootstrapSHA              string `json:"bootstrap-sha,omitempty"`
+	BumpMinorPreMajor         *bool  `json:"bump-minor-pre-major,omitempty"`
+	BumpPatchForMinorPreMajor *bool  `json:"bump-patch-for-minor-pre-major,omitempty"`
+}
+
+var knownPackageConfigKeys = []string{
+	"release-type", "versioning", "package-name", "component", "bootstrap-sha",
+	"bump-minor-pre-major", "bump-patch-for-minor-pre-major",
+}
+
+// topLevelOnlyKeys are the options that release-please only reads from the top level of the file, and
+// that therefore do not become defaults for each package.
+var topLevelOnlyKeys = map[string]bool{
+	"$schema":                          true,
+	"always-link-local":                true,
+	"always-update":                    true,
+	"commit-search-depth":              true,
+	"group-pull-request-title-pattern": true,
+	"last-release-sha":                 true,
+	"plugins":                          true,
+	"release-search-depth":             true,
+	"separate-pull-requests":           true,
+	"sequential-calls":                 true,
+	"signoff":                          true,
+}
+
+// ParseConfig parses the contents of a configuration file.
+func ParseConfig(data []byte) (Config, error) {
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("invalid release-please config: %w", err)
+	}
+	return c, nil
+}
+
+// ReadConfig reads and parses a configuration file.
+func ReadConfig(filePath string) (Config, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return Config{}, err
+	}
+	return ParseConfig(data)
+}
+
+// Marshal returns the configuration as indented JSON with sorted keys and a trailing newline.
+func (c Config) Marshal() ([]byte, error) {
+	return marshalFile(c)
+}
+
+// WriteConfig writes the configuration to a file, replacing any existing content.
+func WriteConfig(filePath string, c Config) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0o644)
+}
+
+// PackageOptions returns the effective options for the package at path, which are its own options with
+// any unset values taken from the top-level defaults. Top-level options that only apply to the file as a
+// whole, such as "separate-pull-requests" or "plugins", are not included. The second return value is
+// false if the configuration has no such package.
+func (c Config) PackageOptions(path string) (PackageConfig, bool) {
+	p, ok := c.Packages[path]
+	if !ok {
+		return PackageConfig{}, false
+	}
+	d := c.Defaults
+	ret := PackageConfig{
+		ReleaseType:               firstNonEmpty(p.ReleaseType, d.ReleaseType),
+		Versioning:                firstNonEmpty(p.Versioning, d.Versioning),
+		PackageName:               firstNonEmpty(p.PackageName, d.PackageName),
+		Component:                 firstNonEmpty(p.Component, d.Component),
+		BootstrapSHA:              firstNonEmpty(p.BootstrapSHA, d.BootstrapSHA),
+		BumpMinorPreMajor:         firstNonNil(p.BumpMinorPreMajor, d.BumpMinorPreMajor),
+		BumpPatchForMinorPreMajor: firstNonNil(p.BumpPatchForMinorPreMajor, d.BumpPatchForMinorPreMajor),
+	}
+	for k, v := range d.Other {
+		if !topLevelOnlyKeys[k] {
+			ret.setOther(k, v)
+		}
+	}
+	for k, v := range p.Other {
+		ret.setOther(k, v)
+	}
+	return ret, true
+}
+
+func (p *PackageConfig) setOther(key string, value json.RawMessage) {
+	if p.Other == nil {
+		p.Other = make(map[string]json.RawMessage)
+	}
+	p.Other[key] = value
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c Config) MarshalJSON() ([]byte, error) {
+	all, err := c.Defaults.toMap()
+	if err != nil {
+		return nil, err // COVERAGE: can only happen if this package has a bug
+	}
+	packages := c.Packages
+	if packages == nil {
+		packages = map[string]PackageConfig{}
+	}
+	if all["packages"], err = json.Marshal(packages); err != nil {
+		return nil, err
+	}
+	return json.Marshal(all)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	packagesData, ok := all["packages"]
+	if !ok {
+		return errors.New(`missing "packages" property`)
+	}
+	var result Config
+	if err := json.Unmarshal(packagesData, &result.Packages); err != nil {
+		return err
+	}
+	delete(all, "packages")
+	if err := result.Defaults.fromMap(all); err != nil {
+		return err
+	}
+	*c = result
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p PackageConfig) MarshalJSON() ([]byte, error) {
+	all, err := p.toMap()
+	if err != nil {
+		return nil, err // COVERAGE: can only happen if this package has a bug
+	}
+	return json.Marshal(all)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PackageConfig) UnmarshalJSON(data []byte) error {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	return p.fromMap(all)
+}
+
+func (p PackageConfig) toMap() (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(packageConfigFields{
+		ReleaseType:               p.ReleaseType,
+		Versioning:                p.Versioning,
+		PackageName:               p.PackageName,
+		Component:                 p.Component,
+		BootstrapSHA:              p.BootstrapSHA,
+		BumpMinorPreMajor:         p.BumpMinorPreMajor,
+		BumpPatchForMinorPreMajor: p.BumpPatchForMinorPreMajor,
+	})
+	if err != nil {
+		return nil, err // COVERAGE: can only happen if this package has a bug
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err // COVERAGE: can only happen if this package has a bug
+	}
+	for k, v := range p.Other {
+		all[k] = v
+	}
+	return all, nil
+}
+
+func (p *PackageConfig) fromMap(all map[string]json.RawMessage) error {
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err // COVERAGE: can only happen if this package has a bug
+	}
+	var fields packageConfigFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	result := PackageConfig{
+		ReleaseType:               fields.ReleaseType,
+		Versioning:                fields.Versioning,
+		PackageName:               fields.PackageName,
+		Component:                 fields.Component,
+		BootstrapSHA:              fields.BootstrapSHA,
+		BumpMinorPreMajor:         fields.BumpMinorPreMajor,
+		BumpPatchForMinorPreMajor: fields.BumpPatchForMinorPreMajor,
+	}
+	for _, k := range knownPackageConfigKeys {
+		delete(all, k)
+	}
+	if len(all) != 0 {
+		result.Other = all
+	}
+	*p = result
+	return nil
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+	return b
+}
+
+func firstNonNil(a, b *bool) *bool {
+	if a != nil {
+		return a
+	}
+	return b
+}
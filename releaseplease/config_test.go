@@ -0,0 +1,173 @@
+package releaseplease
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestReadConfigFromThisRepository(t *testing.T) {
+	c, err := ReadConfig(filepath.Join("..", DefaultConfigFile))
+	require.NoError(t, err)
+	require.Contains(t, c.Packages, ".")
+	assert.Equal(t, "go", c.Packages["."].ReleaseType)
+	assert.Equal(t, "default", c.Packages["."].Versioning)
+	assert.NotEmpty(t, c.Packages["."].BootstrapSHA)
+}
+
+func TestParseConfig(t *testing.T) {
+	c, err := ParseConfig([]byte(`{
+		"release-type": "go",
+		"bump-minor-pre-major": true,
+		"separate-pull-requests": true,
+		"packages": {
+			".": {"package-name": "root", "extra-files": ["a.go"]},
+			"b": {"release-type": "simple", "bump-minor-pre-major": false, "versioning": "always-bump-patch"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, PackageConfig{
+		ReleaseType:       "go",
+		BumpMinorPreMajor: boolPtr(true),
+		Other:             map[string]json.RawMessage{"separate-pull-requests": json.RawMessage("true")},
+	}, c.Defaults)
+	assert.Equal(t, PackageConfig{
+		PackageName: "root",
+		Other:       map[string]json.RawMessage{"extra-files": json.RawMessage(`["a.go"]`)},
+	}, c.Packages["."])
+
+	root, ok := c.PackageOptions(".")
+	require.True(t, ok)
+	assert.Equal(t, PackageConfig{
+		ReleaseType:       "go",
+		PackageName:       "root",
+		BumpMinorPreMajor: boolPtr(true),
+		Other:             map[string]json.RawMessage{"extra-files": json.RawMessage(`["a.go"]`)},
+	}, root)
+
+	b, ok := c.PackageOptions("b")
+	require.True(t, ok)
+	assert.Equal(t, "simple", b.ReleaseType)
+	assert.Equal(t, "always-bump-patch", b.Versioning)
+	assert.Equal(t, boolPtr(false), b.BumpMinorPreMajor)
+
+	_, ok = c.PackageOptions("c")
+	assert.False(t, ok)
+}
+
+func TestPackageOptionsInheritsOnlyPackageScopedOptions(t *testing.T) {
+	c, err := ParseConfig([]byte(`{
+		"separate-pull-requests": true,
+		"plugins": ["node-workspace"],
+		"include-v-in-tag": false,
+		"label": "autorelease: pending",
+		"packages": {
+			".": {},
+			"b": {"label": "release", "signoff": "someone"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	root, ok := c.PackageOptions(".")
+	require.True(t, ok)
+	assert.Equal(t, map[string]json.RawMessage{
+		"include-v-in-tag": json.RawMessage("false"),
+		"label":            json.RawMessage(`"autorelease: pending"`),
+	}, root.Other)
+
+	b, ok := c.PackageOptions("b")
+	require.True(t, ok)
+	assert.Equal(t, map[string]json.RawMessage{
+		"include-v-in-tag": json.RawMessage("false"),
+		"label":            json.RawMessage(`"release"`),
+		"signoff":          json.RawMessage(`"someone"`), // kept if it was given for the package itself
+	}, b.Other)
+
+	c, err = ParseConfig([]byte(`{"separate-pull-requests": true, "packages": {".": {}}}`))
+	require.NoError(t, err)
+	root, _ = c.PackageOptions(".")
+	assert.Nil(t, root.Other)
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"malformed JSON":            `{"packages": `,
+		"not an object":             `[]`,
+		"missing packages":          `{"release-type": "go"}`,
+		"packages not an object":    `{"packages": []}`,
+		"package not an object":     `{"packages": {".": []}}`,
+		"wrong option type":         `{"packages": {".": {"bump-minor-pre-major": "yes"}}}`,
+		"wrong default option type": `{"bump-minor-pre-major": "yes", "packages": {}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	original := `{
+  "bump-minor-pre-major": true,
+  "packages": {
+    ".": {
+      "bootstrap-sha": "c4c898c0c7a420d17e1d72716d1eca9769292103",
+      "extra-files": [
+        "version.go"
+      ],
+      "release-type": "go",
+      "versioning": "default"
+    }
+  },
+  "pull-request-header": "Release"
+}
+`
+	c, err := ParseConfig([]byte(original))
+	require.NoError(t, err)
+	data, err := c.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data))
+
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	require.NoError(t, WriteConfig(path, c))
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(written))
+}
+
+func TestReadConfigErrors(t *testing.T) {
+	_, err := ReadConfig(filepath.Join(t.TempDir(), DefaultConfigFile))
+	assert.Error(t, err)
+}
+
+func TestMarshalConfigErrors(t *testing.T) {
+	invalid := map[string]json.RawMessage{"extra-files": json.RawMessage(`["a.go"`)}
+	for name, c := range map[string]Config{
+		"invalid default option": {Defaults: PackageConfig{Other: invalid}},
+		"invalid package option": {Packages: map[string]PackageConfig{".": {Other: invalid}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.Marshal()
+			assert.Error(t, err)
+
+			path := filepath.Join(t.TempDir(), DefaultConfigFile)
+			assert.Error(t, WriteConfig(path, c))
+			assert.NoFileExists(t, path)
+		})
+	}
+}
+
+func TestMarshalEmptyConfig(t *testing.T) {
+	data, err := Config{}.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"packages\": {}\n}\n", string(data))
+}
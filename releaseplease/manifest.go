@@ -0,0 +1,105 @@
+// Package releaseplease reads and writes the ".release-please-manifest.json" and
+// "release-please-config.json" files used by release-please (https://github.com/googleapis/release-please)
+// in manifest mode, and computes release plans from them without invoking release-please itself.
+package releaseplease
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// DefaultManifestFile is the file name that release-please uses for the manifest by default.
+const DefaultManifestFile = ".release-please-manifest.json"
+
+// Manifest maps each package path, such as "." or "packages/foo", to its current released version.
+type Manifest map[string]semver.Version
+
+// ParseManifest parses the contents of a manifest file. Every version must be valid according to
+// semver.ParseModeStrict; if any is not, the returned error describes each invalid entry.
+func ParseManifest(data []byte) (Manifest, error) {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid release-please manifest: %w", err)
+	}
+	m := make(Manifest, len(raw))
+	var errs []error
+	for _, path := range sortedKeys(raw) {
+		v, err := semver.Parse(raw[path])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("package %q: %q: %w", path, raw[path], err))
+			continue
+		}
+		m[path] = v
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	return m, nil
+}
+
+// ReadManifest reads and parses a manifest file; see ParseManifest.
+func ReadManifest(filePath string) (Manifest, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(data)
+}
+
+// Marshal returns the manifest as indented JSON with sorted keys and a trailing newline, which is the
+// same format that release-please writes.
+func (m Manifest) Marshal() ([]byte, error) {
+	raw := make(map[string]string, len(m))
+	for path, v := range m {
+		raw[path] = v.String()
+	}
+	return marshalFile(raw)
+}
+
+// WriteManifest writes the manifest to a file, replacing any existing content.
+func WriteManifest(filePath string, m Manifest) error {
+	data, err := m.Marshal()
+	if err != nil {
+		return err // COVERAGE: can only happen if this package has a bug
+	}
+	return os.WriteFile(filePath, data, 0o644)
+}
+
+// Paths returns the package paths in the manifest in sorted order.
+func (m Manifest) Paths() []string {
+	return sortedKeys(m)
+}
+
+// Bump replaces the version of the package at path with the result of semver.Version.Bump, and returns
+// the new version. It returns an error if the manifest has no such package.
+func (m Manifest) Bump(path string, kind semver.BumpKind) (semver.Version, error) {
+	v, ok := m[path]
+	if !ok {
+		return semver.Version{}, fmt.Errorf("package %q is not in the release-please manifest", path)
+	}
+	v = v.Bump(kind)
+	m[path] = v
+	return v, nil
+}
+
+func marshalFile(value any) ([]byte, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,88 @@
+package releaseplease
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, s string) semver.Version {
+	v, err := semver.Parse(s)
+	require.NoError(t, err)
+	return v
+}
+
+func TestReadManifestFromThisRepository(t *testing.T) {
+	m, err := ReadManifest(filepath.Join("..", DefaultManifestFile))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"."}, m.Paths())
+	assert.Equal(t, 1, m["."].GetMajor())
+}
+
+func TestParseManifest(t *testing.T) {
+	m, err := ParseManifest([]byte(`{"packages/b": "0.3.0-beta.1", ".": "1.0.4", "packages/a": "2.1.0+x"}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{".", "packages/a", "packages/b"}, m.Paths())
+	assert.Equal(t, mustParse(t, "1.0.4"), m["."])
+	assert.Equal(t, mustParse(t, "2.1.0+x"), m["packages/a"])
+	assert.Equal(t, mustParse(t, "0.3.0-beta.1"), m["packages/b"])
+}
+
+func TestParseManifestErrors(t *testing.T) {
+	t.Run("malformed JSON", func(t *testing.T) {
+		_, err := ParseManifest([]byte(`{".": `))
+		assert.Error(t, err)
+	})
+
+	t.Run("non-string version", func(t *testing.T) {
+		_, err := ParseManifest([]byte(`{".": 1}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadManifest(filepath.Join(t.TempDir(), DefaultManifestFile))
+		assert.Error(t, err)
+	})
+
+	t.Run("every invalid version is reported", func(t *testing.T) {
+		m, err := ParseManifest([]byte(`{"a": "v1.0.0", "b": "1.0.0", "c": "1.0"}`))
+		require.Error(t, err)
+		assert.Nil(t, m)
+		assert.Contains(t, err.Error(), `package "a": "v1.0.0"`)
+		assert.Contains(t, err.Error(), `package "c": "1.0"`)
+		assert.NotContains(t, err.Error(), `package "b"`)
+	})
+}
+
+func TestMarshalManifest(t *testing.T) {
+	m := Manifest{"packages/a": mustParse(t, "2.0.0-rc.1"), ".": mustParse(t, "1.0.4")}
+	data, err := m.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \".\": \"1.0.4\",\n  \"packages/a\": \"2.0.0-rc.1\"\n}\n", string(data))
+
+	path := filepath.Join(t.TempDir(), DefaultManifestFile)
+	require.NoError(t, WriteManifest(path, m))
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, written)
+
+	m2, err := ReadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, m, m2)
+}
+
+func TestManifestBump(t *testing.T) {
+	m := Manifest{".": mustParse(t, "1.0.4")}
+	v, err := m.Bump(".", semver.BumpMinor)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", v.String())
+	assert.Equal(t, v, m["."])
+
+	_, err = m.Bump("other", semver.BumpMinor)
+	assert.Error(t, err)
+}
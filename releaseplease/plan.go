@@ -0,0 +1,72 @@
+package releaseplease
+
+import (
+	"fmt"
+
+	"github.com/launchdarkly/go-semver"
+	"github.com/launchdarkly/go-semver/conventional"
+)
+
+// Release describes a version change planned for one package.
+type Release struct {
+	// Path is the package path as it appears in the manifest and configuration.
+	Path string
+
+	// Current is the version in the manifest.
+	Current semver.Version
+
+	// Next is the version that should be released.
+	Next semver.Version
+
+	// Bump is the kind of change from Current to Next.
+	Bump semver.BumpKind
+}
+
+// Plan computes the releases that release-please would propose, given the commit messages made to each
+// package since its current version. The result contains one Release for each configured package that has
+// at least one releasable commit, in sorted path order.
+//
+// The "default" versioning strategy is applied as described in the conventional package, honoring the
+// "bump-minor-pre-major" and "bump-patch-for-minor-pre-major" options. The "always-bump-patch",
+// "always-bump-minor" and "always-bump-major" strategies apply a fixed kind of bump whenever there is a
+// releasable commit. Any other strategy causes an error, as does a configured package that is missing
+// from the manifest or a commits entry for a package that is not configured.
+func Plan(cfg Config, m Manifest, commits map[string][]string) ([]Release, error) {
+	for path := range commits {
+		if _, ok := cfg.Packages[path]; !ok {
+			return nil, fmt.Errorf("package %q is not in the release-please config", path)
+		}
+	}
+	var releases []Release
+	for _, path := range sortedKeys(cfg.Packages) {
+		opts, _ := cfg.PackageOptions(path)
+		current, ok := m[path]
+		if !ok {
+			return nil, fmt.Errorf("package %q is not in the release-please manifest", path)
+		}
+		_, kind := conventional.NextVersion(current, commits[path], conventional.Options{
+			BumpMinorPreMajor:         boolValue(opts.BumpMinorPreMajor),
+			BumpPatchForMinorPreMajor: boolValue(opts.BumpPatchForMinorPreMajor),
+		})
+		if kind == semver.BumpNone {
+			continue
+		}
+		switch opts.Versioning {
+		case "", "default":
+		case "always-bump-patch":
+			kind = semver.BumpPatch
+		case "always-bump-minor":
+			kind = semver.BumpMinor
+		case "always-bump-major":
+			kind = semver.BumpMajor
+		default:
+			return nil, fmt.Errorf("package %q: unsupported versioning strategy %q", path, opts.Versioning)
+		}
+		releases = append(releases, Release{Path: path, Current: current, Next: current.Bump(kind), Bump: kind})
+	}
+	return releases, nil
+}
+
+func boolValue(b *bool) bool {
+	return b != nil && *b
+}
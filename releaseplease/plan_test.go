@@ -0,0 +1,97 @@
+package releaseplease
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{
+		"bump-minor-pre-major": true,
+		"packages": {
+			".": {},
+			"lib": {"bump-minor-pre-major": false},
+			"svc": {"versioning": "always-bump-minor"},
+			"docs": {}
+		}
+	}`))
+	require.NoError(t, err)
+	m := Manifest{
+		".":    mustParse(t, "1.0.4"),
+		"lib":  mustParse(t, "0.2.0"),
+		"svc":  mustParse(t, "3.1.1"),
+		"docs": mustParse(t, "0.1.0"),
+	}
+
+	releases, err := Plan(cfg, m, map[string][]string{
+		".":    {"fix: a", "feat: b"},
+		"lib":  {"feat!: c"},
+		"svc":  {"fix: d"},
+		"docs": {"docs: e"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Release{
+		{Path: ".", Current: m["."], Next: mustParse(t, "1.1.0"), Bump: semver.BumpMinor},
+		{Path: "lib", Current: m["lib"], Next: mustParse(t, "1.0.0"), Bump: semver.BumpMajor},
+		{Path: "svc", Current: m["svc"], Next: mustParse(t, "3.2.0"), Bump: semver.BumpMinor},
+	}, releases)
+}
+
+func TestPlanVersioningStrategies(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{
+		"packages": {
+			"default": {"versioning": "default"},
+			"patch": {"versioning": "always-bump-patch"},
+			"minor": {"versioning": "always-bump-minor"},
+			"major": {"versioning": "always-bump-major"}
+		}
+	}`))
+	require.NoError(t, err)
+	m := Manifest{}
+	commits := map[string][]string{}
+	for path := range cfg.Packages {
+		m[path] = mustParse(t, "1.2.3")
+		commits[path] = []string{"feat: a"}
+	}
+
+	releases, err := Plan(cfg, m, commits)
+	require.NoError(t, err)
+	assert.Equal(t, []Release{
+		{Path: "default", Current: m["default"], Next: mustParse(t, "1.3.0"), Bump: semver.BumpMinor},
+		{Path: "major", Current: m["major"], Next: mustParse(t, "2.0.0"), Bump: semver.BumpMajor},
+		{Path: "minor", Current: m["minor"], Next: mustParse(t, "1.3.0"), Bump: semver.BumpMinor},
+		{Path: "patch", Current: m["patch"], Next: mustParse(t, "1.2.4"), Bump: semver.BumpPatch},
+	}, releases)
+}
+
+func TestPlanPreMajorOptionFromDefaults(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{"bump-minor-pre-major": true, "packages": {".": {}}}`))
+	require.NoError(t, err)
+	releases, err := Plan(cfg, Manifest{".": mustParse(t, "0.9.0")}, map[string][]string{".": {"fix!: a"}})
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, "0.10.0", releases[0].Next.String())
+}
+
+func TestPlanErrors(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`{"packages": {".": {}, "odd": {"versioning": "service-pack"}}}`))
+	require.NoError(t, err)
+
+	_, err = Plan(cfg, Manifest{".": mustParse(t, "1.0.0")}, nil)
+	assert.Error(t, err, "configured package missing from manifest")
+
+	m := Manifest{".": mustParse(t, "1.0.0"), "odd": mustParse(t, "1.0.0")}
+	_, err = Plan(cfg, m, map[string][]string{"unknown": {"fix: a"}})
+	assert.Error(t, err, "commits for unconfigured package")
+
+	_, err = Plan(cfg, m, map[string][]string{"odd": {"fix: a"}})
+	assert.Error(t, err, "unsupported versioning strategy")
+
+	releases, err := Plan(cfg, m, map[string][]string{"odd": {"chore: a"}})
+	assert.NoError(t, err, "unsupported strategy is irrelevant if nothing is released")
+	assert.Len(t, releases, 0)
+}
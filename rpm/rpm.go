@@ -0,0 +1,243 @@
+// Package rpm implements the version scheme used by RPM packages, as found in Red Hat, Fedora, SUSE and
+// other distributions, with the same ordering rules as rpm itself.
+//
+// An RPM version is an "EVR" of the form "[epoch:]version[-release]", for instance "3.2.1-4.el9" or
+// "1:1.1.1k-12.el8_9". See https://rpm-software-management.github.io/rpm/manual/dependencies.html#versioning.
+//
+// Like the semver package, this package does not use regular expressions, and neither parsing nor
+// comparison causes any heap allocations.
+package rpm
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Version is a parsed RPM epoch-version-release.
+//
+// Versions are compared with ComparePrecedence, which implements semver.Comparable. Two versions that are
+// spelled differently may have the same precedence: for instance, "1.0", "0:1.0", "1.00" and "1_0" are
+// all equivalent.
+type Version struct {
+	epoch   int
+	version string
+	release string
+}
+
+var errInvalidVersion = errors.New("invalid RPM version")
+
+// Parse attempts to parse a string into a Version.
+//
+// The version and the release, if any, may contain only alphanumerics and the characters ".", "_", "+",
+// "~" and "^". The release is whatever follows the hyphen, if any; there may be only one hyphen.
+// The epoch, if any, is whatever precedes the colon, and must be a non-negative integer.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and Version{} as the first.
+func Parse(s string) (Version, error) {
+	var v Version
+	rest := s
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		epoch, ok := parseEpoch(s[:i])
+		if !ok {
+			return Version{}, errInvalidVersion
+		}
+		v.epoch = epoch
+		rest = s[i+1:]
+	}
+	v.version = rest
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.version, v.release = rest[:i], rest[i+1:]
+		if v.release == "" || !everyChar(v.release, isVersionChar) {
+			return Version{}, errInvalidVersion
+		}
+	}
+	if v.version == "" || !everyChar(v.version, isVersionChar) {
+		return Version{}, errInvalidVersion
+	}
+	return v, nil
+}
+
+// Epoch returns the epoch of the version, or zero if it did not have one.
+func (v Version) Epoch() int {
+	return v.epoch
+}
+
+// Version returns the version component, for instance "3.2.1" in "3.2.1-4.el9".
+func (v Version) Version() string {
+	return v.version
+}
+
+// Release returns the release component, for instance "4.el9" in "3.2.1-4.el9", or an empty string if
+// the version did not have one.
+func (v Version) Release() string {
+	return v.release
+}
+
+// String returns the version in the same form it was parsed from, except that an epoch of zero is omitted.
+func (v Version) String() string {
+	s := v.version
+	if v.release != "" {
+		s += "-" + v.release
+	}
+	if v.epoch != 0 {
+		s = strconv.Itoa(v.epoch) + ":" + s
+	}
+	return s
+}
+
+// ComparePrecedence compares this Version to another Version with the same rules as rpm. It returns -1
+// if v has lower precedence than other, 1 if v has higher precedence, or 0 if the same.
+//
+// Epochs are compared numerically. The versions, and then the releases, are each compared with the
+// rpmvercmp algorithm: they are split into runs of digits and runs of letters, ignoring any other
+// characters, and the runs are compared in turn. Runs of digits are compared numerically and are higher
+// than runs of letters, which are compared lexically. A "~" sorts before anything, even the end of the
+// string, so "1.0~rc1" is lower than "1.0"; a "^" sorts after the end of the string but before anything
+// else, so "1.0^git1" is higher than "1.0" but lower than "1.0.1". A missing release is lower than any
+// release.
+func (v Version) ComparePrecedence(other Version) int {
+	if v.epoch != other.epoch {
+		if v.epoch < other.epoch {
+			return -1
+		}
+		return 1
+	}
+	if result := compareSegments(v.version, other.version); result != 0 {
+		return result
+	}
+	return compareSegments(v.release, other.release)
+}
+
+// compareSegments is the equivalent of rpm's rpmvercmp function.
+func compareSegments(a, b string) int {
+	if a == b {
+		return 0
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && isSeparator(a[i]) {
+			i++
+		}
+		for j < len(b) && isSeparator(b[j]) {
+			j++
+		}
+
+		if charAt(a, i) == '~' || charAt(b, j) == '~' {
+			if charAt(a, i) != '~' {
+				return 1
+			}
+			if charAt(b, j) != '~' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if charAt(a, i) == '^' || charAt(b, j) == '^' {
+			if i >= len(a) {
+				return -1
+			}
+			if j >= len(b) {
+				return 1
+			}
+			if a[i] != '^' {
+				return 1
+			}
+			if b[j] != '^' {
+				return -1
+			}
+			i++
+			j++
+			continue
+		}
+
+		if i >= len(a) || j >= len(b) {
+			break
+		}
+
+		isNumeric := isDigit(a[i])
+		matches := isLetter
+		if isNumeric {
+			matches = isDigit
+		}
+		endA, endB := i, j
+		for endA < len(a) && matches(a[endA]) {
+			endA++
+		}
+		for endB < len(b) && matches(b[endB]) {
+			endB++
+		}
+		if endB == j {
+			// the segments are of different types; a numeric segment is always higher
+			if isNumeric {
+				return 1
+			}
+			return -1
+		}
+
+		segA, segB := a[i:endA], b[j:endB]
+		if isNumeric {
+			segA, segB = strings.TrimLeft(segA, "0"), strings.TrimLeft(segB, "0")
+			if len(segA) != len(segB) {
+				if len(segA) < len(segB) {
+					return -1
+				}
+				return 1
+			}
+		}
+		if result := strings.Compare(segA, segB); result != 0 {
+			return result
+		}
+		i, j = endA, endB
+	}
+	switch {
+	case i >= len(a) && j >= len(b):
+		return 0
+	case i >= len(a):
+		return -1
+	default:
+		return 1
+	}
+}
+
+func charAt(s string, i int) byte {
+	if i >= len(s) {
+		return 0
+	}
+	return s[i]
+}
+
+func parseEpoch(s string) (int, bool) {
+	if s == "" || !everyChar(s, isDigit) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
+
+func everyChar(s string, fn func(byte) bool) bool {
+	for i := 0; i < len(s); i++ {
+		if !fn(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func isLetter(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isSeparator(ch byte) bool {
+	return !isDigit(ch) && !isLetter(ch) && ch != '~' && ch != '^'
+}
+
+func isVersionChar(ch byte) bool {
+	return isDigit(ch) || isLetter(ch) || ch == '.' || ch == '_' || ch == '+' || ch == '~' || ch == '^'
+}
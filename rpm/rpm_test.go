@@ -0,0 +1,166 @@
+package rpm
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ semver.Comparable[Version] = Version{}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		input, version, release string
+		epoch                   int
+	}{
+		{"1.0", "1.0", "", 0},
+		{"3.2.1-4.el9", "3.2.1", "4.el9", 0},
+		{"1:1.1.1k-12.el8_9", "1.1.1k", "12.el8_9", 1},
+		{"0:1.0", "1.0", "", 0},
+		{"1.0~rc1^git2+fix-0.1", "1.0~rc1^git2+fix", "0.1", 0},
+		{"a", "a", "", 0},
+	} {
+		t.Run(test.input, func(t *testing.T) {
+			v, err := Parse(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.epoch, v.Epoch())
+			assert.Equal(t, test.version, v.Version())
+			assert.Equal(t, test.release, v.Release())
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", "-1", "1.0-", "1.0-1-2", ":1.0", "a:1.0", "1:", "1:2:3", "1.0 ", "1.0/2", "99999999999999999999:1.0",
+		"1.0é",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := Parse(s)
+			assert.Error(t, err)
+			assert.Equal(t, Version{}, v)
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	for input, expected := range map[string]string{
+		"1.0":               "1.0",
+		"0:1.0-1":           "1.0-1",
+		"1:1.1.1k-12.el8_9": "1:1.1.1k-12.el8_9",
+	} {
+		v, err := Parse(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, v.String())
+	}
+}
+
+func TestCompareSegments(t *testing.T) {
+	// These are from the rpm test suite (tests/rpmvercmp.at)
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "1.0", 0},
+		{"1.0", "2.0", -1},
+		{"2.0.1", "2.0.1", 0},
+		{"2.0", "2.0.1", -1},
+		{"2.0.1a", "2.0.1a", 0},
+		{"2.0.1a", "2.0.1", 1},
+		{"5.5p1", "5.5p1", 0},
+		{"5.5p1", "5.5p2", -1},
+		{"5.5p10", "5.5p10", 0},
+		{"5.5p1", "5.5p10", -1},
+		{"10xyz", "10.1xyz", -1},
+		{"xyz10", "xyz10", 0},
+		{"xyz10", "xyz10.1", -1},
+		{"xyz.4", "xyz.4", 0},
+		{"xyz.4", "8", -1},
+		{"xyz.4", "2", -1},
+		{"5.5p2", "5.6p1", -1},
+		{"5.6p1", "6.5p1", -1},
+		{"6.0.rc1", "6.0", 1},
+		{"10b2", "10a1", 1},
+		{"1.0aa", "1.0aa", 0},
+		{"1.0a", "1.0aa", -1},
+		{"10.0001", "10.0001", 0},
+		{"10.0001", "10.1", 0},
+		{"10.0001", "10.0039", -1},
+		{"4.999.9", "5.0", -1},
+		{"20101121", "20101121", 0},
+		{"20101121", "20101122", -1},
+		{"2_0", "2_0", 0},
+		{"2.0", "2_0", 0},
+		{"a", "a", 0},
+		{"a+", "a+", 0},
+		{"a+", "a_", 0},
+		{"+a", "+a", 0},
+		{"+a", "_a", 0},
+		{"+_", "_+", 0},
+		{"+", "_", 0},
+		{"1.0fc4", "1.fc4", 1},
+		{"3.0.0_fc", "3.0.0.fc", 0},
+		{"1++", "1_", 0},
+		{"1.0~rc1", "1.0~rc1", 0},
+		{"1.0~rc1", "1.0", -1},
+		{"1.0~rc1", "1.0~rc2", -1},
+		{"1.0~rc1~git123", "1.0~rc1~git123", 0},
+		{"1.0~rc1~git123", "1.0~rc1", -1},
+		{"1.0^", "1.0^", 0},
+		{"1.0^", "1.0", 1},
+		{"1.0", "1.0git1^", -1},
+		{"1.0^git1", "1.0^git1", 0},
+		{"1.0^git1", "1.0", 1},
+		{"1.0^git1", "1.01", -1},
+		{"1.0^20160101", "1.0^20160101", 0},
+		{"1.0^20160101", "1.0.1", -1},
+		{"1.0^20160102", "1.0^20160101^git1", 1},
+		{"1.0~rc1^git1", "1.0~rc1^git1", 0},
+		{"1.0~rc1^git1", "1.0~rc1", 1},
+		{"1.0^git1~pre", "1.0^git1", -1},
+		{"1.0^git1", "1.0~rc1", 1},
+		{"", "1", -1},
+	} {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			assert.Equal(t, test.expected, compareSegments(test.a, test.b))
+			assert.Equal(t, -test.expected, compareSegments(test.b, test.a))
+		})
+	}
+}
+
+func TestComparePrecedence(t *testing.T) {
+	for _, test := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"1.0", "0:1.0", 0},
+		{"1:1.0", "2.0", 1},
+		{"3.2.1-4.el9", "3.2.1-4.el9", 0},
+		{"3.2.1-4.el9", "3.2.1-10.el9", -1},
+		{"3.2.1-4.el9", "3.2.2-1.el9", -1},
+		{"3.2.1", "3.2.1-4.el9", -1},
+		{"1:1.1.1k-12.el8_9", "1:1.1.1k-12.el8_10", -1},
+		{"1:1.1.1k-12.el8_9", "1:1.1.1l-1.el8", -1},
+	} {
+		t.Run(test.a+" vs "+test.b, func(t *testing.T) {
+			a, err := Parse(test.a)
+			require.NoError(t, err)
+			b, err := Parse(test.b)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, a.ComparePrecedence(b))
+			assert.Equal(t, -test.expected, b.ComparePrecedence(a))
+		})
+	}
+}
+
+func TestParseAndCompareDoNotAllocate(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		a, _ := Parse("1:1.1.1k-12.el8_9")
+		b, _ := Parse("1:1.1.1k-12.el8_10")
+		_ = a.ComparePrecedence(b)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
@@ -2,10 +2,11 @@ package semver
 
 import "unicode"
 
-// An extremely simple tokenizing helper that only handles ASCII strings.
+// An extremely simple tokenizing helper that only handles ASCII strings. It can read from either a string
+// or a byte slice, so that byte slices can be parsed without first converting them to strings.
 
-type simpleASCIIScanner struct {
-	source string
+type simpleASCIIScanner[S string | []byte] struct {
+	source S
 	length int
 	pos    int
 }
@@ -19,15 +20,15 @@ func noTerminator(rune) bool {
 	return false
 }
 
-func newSimpleASCIIScanner(source string) simpleASCIIScanner {
-	return simpleASCIIScanner{source: source, length: len(source)}
+func newSimpleASCIIScanner[S string | []byte](source S) simpleASCIIScanner[S] {
+	return simpleASCIIScanner[S]{source: source, length: len(source)}
 }
 
-func (s *simpleASCIIScanner) eof() bool {
+func (s *simpleASCIIScanner[S]) eof() bool {
 	return s.pos >= s.length
 }
 
-func (s *simpleASCIIScanner) peek() int8 {
+func (s *simpleASCIIScanner[S]) peek() int8 {
 	if s.pos >= s.length {
 		return scannerEOF
 	}
@@ -38,7 +39,7 @@ func (s *simpleASCIIScanner) peek() int8 {
 	return int8(ch)
 }
 
-func (s *simpleASCIIScanner) next() int8 {
+func (s *simpleASCIIScanner[S]) next() int8 {
 	ch := s.peek()
 	if ch > 0 {
 		s.pos++
@@ -46,7 +47,7 @@ func (s *simpleASCIIScanner) next() int8 {
 	return ch
 }
 
-func (s *simpleASCIIScanner) readUntil(terminatorFn func(rune) bool) (substring string, terminatedBy int8) {
+func (s *simpleASCIIScanner[S]) readUntil(terminatorFn func(rune) bool) (substring S, terminatedBy int8) {
 	startPos := s.pos
 	var ch int8
 	for {
@@ -0,0 +1,128 @@
+package semver
+
+import (
+	"errors"
+	"strings"
+)
+
+// Semantic Versioning 1.0.0 (https://semver.org/spec/v1.0.0.html) differs from 2.0.0 in that a version
+// can have a "special version" string instead of a prerelease component, and cannot have build metadata:
+//
+//	A special version number MAY be denoted by appending an arbitrary string immediately following the
+//	patch version. The string MUST be comprised of only alphanumerics plus dash [0-9A-Za-z-] and MUST
+//	begin with an alpha character [A-Za-z]. Special versions satisfy but have a lower precedence than the
+//	associated normal version. Precedence SHOULD be determined by lexicographic ASCII sort order. For
+//	instance: 1.0.0beta1 < 1.0.0beta2 < 1.0.0.
+//
+// Every SemVer 1.0.0 version can be represented as a 2.0.0 Version by treating the special version as a
+// prerelease component with a single identifier; since that identifier always begins with a letter, the
+// 2.0.0 precedence rules then give the same result as the lexicographic rule.
+
+var (
+	errSemVer1Build      = errors.New("build metadata is not allowed in Semantic Versioning 1.0.0")
+	errSemVer1Prerelease = errors.New(
+		"prerelease must be a single identifier beginning with a letter in Semantic Versioning 1.0.0")
+)
+
+// ParseSemVer1 attempts to parse a string that follows the Semantic Versioning 1.0.0 standard, such as
+// "1.0.0" or "1.0.0beta1", and converts it to an equivalent Version in which the special version is the
+// prerelease component: "1.0.0beta1" produces the same Version as parsing "1.0.0-beta1". It is equivalent
+// to ParseAs(s, ParseModeSemVer1).
+//
+// A hyphen between the patch version and the special version, as in "1.0.0-beta1", is also accepted
+// since it was commonly used with SemVer 1.0.0. Build metadata ("+build") and dot-separated prerelease
+// identifiers are not allowed. As in the other parsing modes, numeric components must not have leading
+// zeroes.
+//
+// If parsing fails, it returns a non-nil error as the second return value, and Version{} as the first.
+func ParseSemVer1(s string) (Version, error) {
+	return parseSemVer1(s)
+}
+
+func parseSemVer1[S string | []byte](s S) (Version, error) {
+	scanner := newSimpleASCIIScanner(s)
+
+	var result Version
+	var term int8
+	var ok bool
+
+	result.major, term, ok = requirePositiveIntegerComponent(&scanner, dotTerminator)
+	if !ok || term != '.' {
+		return Version{}, errInvalidSemver
+	}
+	result.minor, term, ok = requirePositiveIntegerComponent(&scanner, dotTerminator)
+	if !ok || term != '.' {
+		return Version{}, errInvalidSemver
+	}
+	result.patch, term, ok = requirePositiveIntegerComponent(&scanner, nonDigitTerminator)
+	if !ok {
+		return Version{}, errInvalidSemver
+	}
+	if term == scannerEOF {
+		return result, nil
+	}
+
+	// The scanner has consumed the character that terminated the patch version; unless it was a hyphen
+	// separator, it is the first character of the special version.
+	rest, restTerm := scanner.readUntil(noTerminator)
+	special := rest
+	if term != '-' {
+		special = s[len(s)-len(rest)-1:]
+	}
+	if len(special) == 0 || restTerm == scannerNonASCII || !isLetter(rune(special[0])) ||
+		!everyChar(special, isAlphanumericOrHyphen) {
+		return Version{}, errInvalidSemver
+	}
+	result.prerelease = string(special)
+	return result, nil
+}
+
+// SemVer1String returns the version in Semantic Versioning 1.0.0 format, in which any prerelease
+// component immediately follows the patch version, as in "1.0.0beta1".
+//
+// It returns an error if the version has build metadata, or if its prerelease component is not a
+// single identifier that begins with a letter, since those cannot be represented in SemVer 1.0.0.
+func (v Version) SemVer1String() (string, error) {
+	if v.build != "" {
+		return "", errSemVer1Build
+	}
+	if v.prerelease != "" && (!isLetter(rune(v.prerelease[0])) || strings.IndexByte(v.prerelease, '.') >= 0) {
+		return "", errSemVer1Prerelease
+	}
+	s := v.String()
+	if v.prerelease != "" {
+		s = s[:len(s)-len(v.prerelease)-1] + v.prerelease
+	}
+	return s, nil
+}
+
+// ComparePrecedenceSemVer1 compares this Version to another Version according to the precedence rules of
+// Semantic Versioning 1.0.0, in which the entire prerelease component is compared as a string in
+// lexicographic ASCII order. It returns -1 if v has lower precedence than other, 1 if v has higher
+// precedence, or 0 if the same.
+//
+// For versions that are valid in SemVer 1.0.0, this always gives the same result as ComparePrecedence.
+// For other versions the results can differ: for instance, "1.0.0-rc.10" has lower precedence than
+// "1.0.0-rc.9" according to this method, but higher precedence according to ComparePrecedence.
+func (v Version) ComparePrecedenceSemVer1(other Version) int {
+	if d := v.normalVersion().ComparePrecedence(other.normalVersion()); d != 0 {
+		return d
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	case v.prerelease < other.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// normalVersion returns the version without its prerelease and build components.
+func (v Version) normalVersion() Version {
+	return Version{major: v.major, minor: v.minor, patch: v.patch}
+}
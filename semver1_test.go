@@ -0,0 +1,109 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSemVer1(t *testing.T) {
+	parseFns := map[string]func(string) (Version, error){
+		"ParseSemVer1(s)":              ParseSemVer1,
+		"ParseAs(s, ParseModeSemVer1)": func(s string) (Version, error) { return ParseAs(s, ParseModeSemVer1) },
+		"ParseBytesAs(b, ParseModeSemVer1)": func(s string) (Version, error) {
+			return ParseBytesAs([]byte(s), ParseModeSemVer1)
+		},
+	}
+	for name, parseFn := range parseFns {
+		t.Run(name, func(t *testing.T) {
+			t.Run("valid", func(t *testing.T) {
+				t.Run("normal version", parsingShouldSucceed(parseFn, "1.2.3", 1, 2, 3, "", ""))
+				t.Run("multi-digit", parsingShouldSucceed(parseFn, "10.20.30", 10, 20, 30, "", ""))
+				t.Run("special version", parsingShouldSucceed(parseFn, "1.0.0beta1", 1, 0, 0, "beta1", ""))
+				t.Run("special version with hyphens", parsingShouldSucceed(parseFn, "1.0.0rc-1-x", 1, 0, 0, "rc-1-x", ""))
+				t.Run("special version after hyphen", parsingShouldSucceed(parseFn, "1.0.10-RC2", 1, 0, 10, "RC2", ""))
+				t.Run("single letter", parsingShouldSucceed(parseFn, "1.0.0a", 1, 0, 0, "a", ""))
+			})
+
+			t.Run("invalid", func(t *testing.T) {
+				t.Run("missing patch", parsingShouldFail(parseFn, "1.0"))
+				t.Run("leading zero", parsingShouldFail(parseFn, "1.02.0"))
+				t.Run("special must begin with letter", parsingShouldFail(parseFn, "1.0.0-1"))
+				t.Run("special must begin with letter after hyphen", parsingShouldFail(parseFn, "1.0.0--a"))
+				t.Run("empty special", parsingShouldFail(parseFn, "1.0.0-"))
+				t.Run("dotted special", parsingShouldFail(parseFn, "1.0.0-beta.1"))
+				t.Run("build metadata", parsingShouldFail(parseFn, "1.0.0+build"))
+				t.Run("special with build metadata", parsingShouldFail(parseFn, "1.0.0beta+build"))
+				t.Run("non-alphanumeric special", parsingShouldFail(parseFn, "1.0.0beta!"))
+				t.Run("non-ASCII special", parsingShouldFail(parseFn, "1.0.0beta🔥"))
+				t.Run("non-ASCII after patch", parsingShouldFail(parseFn, "1.0.0🔥"))
+				t.Run("empty", parsingShouldFail(parseFn, ""))
+			})
+		})
+	}
+}
+
+func TestSemVer1String(t *testing.T) {
+	for input, expected := range map[string]string{
+		"1.2.3":         "1.2.3",
+		"1.0.0-beta1":   "1.0.0beta1",
+		"1.0.0-rc-1":    "1.0.0rc-1",
+		"10.20.30-RC2x": "10.20.30RC2x",
+	} {
+		v, err := Parse(input)
+		require.NoError(t, err)
+		s, err := v.SemVer1String()
+		require.NoError(t, err)
+		assert.Equal(t, expected, s)
+
+		v1, err := ParseSemVer1(s)
+		require.NoError(t, err)
+		assert.Equal(t, v, v1)
+	}
+
+	for _, input := range []string{"1.0.0+build", "1.0.0-beta.1", "1.0.0-1", "1.0.0-1beta", "1.0.0--x"} {
+		v, err := Parse(input)
+		require.NoError(t, err)
+		s, err := v.SemVer1String()
+		assert.Error(t, err, input)
+		assert.Equal(t, "", s)
+	}
+}
+
+func TestComparePrecedenceSemVer1(t *testing.T) {
+	for _, test := range []struct {
+		v1, v2 string
+		result int
+	}{
+		{"1.0.0beta1", "1.0.0beta2", -1},
+		{"1.0.0beta2", "1.0.0", -1},
+		{"1.0.0beta10", "1.0.0beta2", -1},
+		{"1.0.0RC1", "1.0.0beta1", -1},
+		{"1.0.0alpha", "1.0.0alpha", 0},
+		{"1.0.0", "1.0.0", 0},
+		{"2.0.0alpha", "1.9.9", 1},
+		{"1.1.0", "1.0.9zzz", 1},
+	} {
+		v1, err := ParseSemVer1(test.v1)
+		require.NoError(t, err)
+		v2, err := ParseSemVer1(test.v2)
+		require.NoError(t, err)
+		assert.Equal(t, test.result, v1.ComparePrecedenceSemVer1(v2), "%s vs %s", test.v1, test.v2)
+		assert.Equal(t, -test.result, v2.ComparePrecedenceSemVer1(v1), "%s vs %s", test.v2, test.v1)
+		assert.Equal(t, test.result, v1.ComparePrecedence(v2), "2.0.0 rules agree for %s vs %s", test.v1, test.v2)
+	}
+
+	t.Run("whole prerelease is compared lexically", func(t *testing.T) {
+		v1, _ := Parse("1.0.0-rc.10")
+		v2, _ := Parse("1.0.0-rc.9")
+		assert.Equal(t, -1, v1.ComparePrecedenceSemVer1(v2))
+		assert.Equal(t, 1, v1.ComparePrecedence(v2))
+	})
+
+	t.Run("build metadata is ignored", func(t *testing.T) {
+		v1, _ := Parse("1.0.0-a+x")
+		v2, _ := Parse("1.0.0-a+y")
+		assert.Equal(t, 0, v1.ComparePrecedenceSemVer1(v2))
+	})
+}
@@ -0,0 +1,146 @@
+// Package semvertemplate provides template functions for working with semantic versions in text/template
+// and html/template, such as:
+//
+//	{{ if semverCompare ">=1.20" .KubeVersion }}...{{ end }}
+//	{{ .Chart.Version | semverBump "minor" }}
+//
+// Versions are parsed with semver.ParseModeAllowMissingMinorAndPatch, after removing any "v" prefix, so
+// "v1.20" is the same as "1.20.0". Constraints are range expressions as described by semver.Range. If a
+// version or constraint cannot be parsed, the function returns an error, which causes template execution
+// to fail with a message that includes the function name and the invalid value.
+//
+// Every function that takes a version accepts either a string or a semver.Version.
+package semvertemplate
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// FuncMap returns the template functions, for use with the Funcs method of text/template.Template:
+//
+//   - semver VERSION returns the parsed semver.Version, whose methods can then be called in the template,
+//     as in {{ (semver .Version).GetPrerelease }}. When printed, as in {{ semver .Version }}, it is written
+//     without its build metadata; {{ (semver .Version).String }} includes it.
+//   - semverCompare CONSTRAINT VERSION returns true if the version satisfies the constraint. It has the same
+//     argument order as the function of the same name in the Sprig library, which is used by Helm, but not
+//     always the same result: semver.Range compares versions purely by precedence, so prerelease versions
+//     are included, and {{ semverCompare ">=1.20" "v1.20.3-gke.100" }} is true here but false in Sprig.
+//     Constraints written with the "-0" idiom, such as ">=1.20.0-0", include prereleases in both. To exclude
+//     prereleases as Sprig does for a constraint that has none, also check the prerelease component:
+//     {{ if and (semverCompare ">=1.20" .Version) (not (semver .Version).GetPrerelease) }}.
+//   - semverSatisfies VERSION CONSTRAINT is the same, with the argument order of node-semver's satisfies.
+//   - semverBump KIND VERSION returns the next version for the kind of change, which is "major", "minor"
+//     or "patch"; see semver.Version.Bump.
+//   - semverMajor VERSION, semverMinor VERSION and semverPatch VERSION return a numeric component.
+//   - semverPrecedence VERSION1 VERSION2 returns -1, 0 or 1, as semver.Version.ComparePrecedence does.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"semver":           parseVersion,
+		"semverCompare":    semverCompare,
+		"semverSatisfies":  semverSatisfies,
+		"semverBump":       semverBump,
+		"semverMajor":      semverMajor,
+		"semverMinor":      semverMinor,
+		"semverPatch":      semverPatch,
+		"semverPrecedence": semverPrecedence,
+	}
+}
+
+// HTMLFuncMap returns the same functions as FuncMap, for use with the Funcs method of html/template.Template.
+func HTMLFuncMap() htmltemplate.FuncMap {
+	return htmltemplate.FuncMap(FuncMap())
+}
+
+func parseVersion(value any) (semver.Version, error) {
+	return toVersion("semver", value)
+}
+
+func semverCompare(constraint string, value any) (bool, error) {
+	return satisfies("semverCompare", value, constraint)
+}
+
+func semverSatisfies(value any, constraint string) (bool, error) {
+	return satisfies("semverSatisfies", value, constraint)
+}
+
+func semverBump(kind string, value any) (semver.Version, error) {
+	var bumpKind semver.BumpKind
+	switch kind {
+	case "major":
+		bumpKind = semver.BumpMajor
+	case "minor":
+		bumpKind = semver.BumpMinor
+	case "patch":
+		bumpKind = semver.BumpPatch
+	default:
+		return semver.Version{}, fmt.Errorf(`semverBump: invalid kind %q; must be "major", "minor" or "patch"`, kind)
+	}
+	v, err := toVersion("semverBump", value)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	return v.Bump(bumpKind), nil
+}
+
+func semverMajor(value any) (int, error) {
+	v, err := toVersion("semverMajor", value)
+	return v.GetMajor(), err
+}
+
+func semverMinor(value any) (int, error) {
+	v, err := toVersion("semverMinor", value)
+	return v.GetMinor(), err
+}
+
+func semverPatch(value any) (int, error) {
+	v, err := toVersion("semverPatch", value)
+	return v.GetPatch(), err
+}
+
+func semverPrecedence(value1, value2 any) (int, error) {
+	v1, err := toVersion("semverPrecedence", value1)
+	if err != nil {
+		return 0, err
+	}
+	v2, err := toVersion("semverPrecedence", value2)
+	if err != nil {
+		return 0, err
+	}
+	return v1.ComparePrecedence(v2), nil
+}
+
+func satisfies(funcName string, value any, constraint string) (bool, error) {
+	r, err := semver.ParseRange(constraint)
+	if err != nil {
+		return false, fmt.Errorf("%s: invalid constraint %q: %w", funcName, constraint, err)
+	}
+	v, err := toVersion(funcName, value)
+	if err != nil {
+		return false, err
+	}
+	return r.Contains(v), nil
+}
+
+func toVersion(funcName string, value any) (semver.Version, error) {
+	switch value := value.(type) {
+	case semver.Version:
+		return value, nil
+	case string:
+		s := value
+		if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+			s = s[1:]
+		}
+		v, err := semver.ParseAs(s, semver.ParseModeAllowMissingMinorAndPatch)
+		if err != nil {
+			return semver.Version{}, fmt.Errorf("%s: invalid version %q: %w", funcName, value, err)
+		}
+		return v, nil
+	default:
+		return semver.Version{}, fmt.Errorf("%s: expected a version string or semver.Version, got %T", funcName, value)
+	}
+}
@@ -0,0 +1,94 @@
+package semvertemplate
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func execute(t *testing.T, text string, data any) (string, error) {
+	tmpl, err := template.New("test").Funcs(FuncMap()).Parse(text)
+	require.NoError(t, err)
+	var sb strings.Builder
+	err = tmpl.Execute(&sb, data)
+	return sb.String(), err
+}
+
+func TestFunctions(t *testing.T) {
+	version, err := semver.Parse("1.2.3-beta+build")
+	require.NoError(t, err)
+	data := map[string]any{
+		"KubeVersion": "v1.20.3-gke.100",
+		"Version":     version,
+		"Old":         "1.19",
+	}
+	for _, test := range []struct {
+		template string
+		expected string
+	}{
+		{`{{ semver "1.2" }}`, "1.2.0"},
+		{`{{ (semver .KubeVersion).GetPrerelease }}`, "gke.100"},
+		{`{{ semver .Version }}`, "1.2.3-beta"},
+		{`{{ (semver .Version).String }}`, "1.2.3-beta+build"},
+		{`{{ semverCompare ">=1.20" .KubeVersion }}`, "true"},
+		{`{{ semverCompare "<1.20" .KubeVersion }}`, "false"},
+		{`{{ semverCompare ">=1.20.0-0" .KubeVersion }}`, "true"},
+		{`{{ and (semverCompare ">=1.20" .KubeVersion) (not (semver .KubeVersion).GetPrerelease) }}`, "false"},
+		{`{{ and (semverCompare ">=1.20" "v1.20.3") (not (semver "v1.20.3").GetPrerelease) }}`, "true"},
+		{`{{ if semverCompare ">=1.20" .Old }}new{{ else }}old{{ end }}`, "old"},
+		{`{{ semverCompare "^1.2.0-0" .Version }}`, "true"},
+		{`{{ semverSatisfies .KubeVersion "1.20.x" }}`, "true"},
+		{`{{ semverSatisfies "2.0" "1.x || >=2.1" }}`, "false"},
+		{`{{ .Version | semverBump "minor" }}`, "1.3.0"},
+		{`{{ .Version | semverBump "patch" }}`, "1.2.3"},
+		{`{{ "0.9" | semverBump "major" }}`, "1.0.0"},
+		{`{{ semverMajor .KubeVersion }}.{{ semverMinor .KubeVersion }}.{{ semverPatch .KubeVersion }}`, "1.20.3"},
+		{`{{ semverPrecedence .Old .KubeVersion }}`, "-1"},
+		{`{{ semverPrecedence "1.2.3+a" "1.2.3+b" }}`, "0"},
+	} {
+		t.Run(test.template, func(t *testing.T) {
+			result, err := execute(t, test.template, data)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, test := range []struct {
+		template string
+		message  string
+	}{
+		{`{{ semver "not-a-version" }}`, `semver: invalid version "not-a-version"`},
+		{`{{ semver 3 }}`, "semver: expected a version string or semver.Version, got int"},
+		{`{{ semverCompare ">=1.20" "1.x" }}`, `semverCompare: invalid version "1.x"`},
+		{`{{ semverCompare ">>1" "1.0" }}`, `semverCompare: invalid constraint ">>1"`},
+		{`{{ semverSatisfies "1.0" "1 ||| 2" }}`, `semverSatisfies: invalid constraint "1 ||| 2"`},
+		{`{{ semverBump "huge" "1.0" }}`, `semverBump: invalid kind "huge"`},
+		{`{{ semverBump "major" "vv1" }}`, `semverBump: invalid version "vv1"`},
+		{`{{ semverMajor "" }}`, `semverMajor: invalid version ""`},
+		{`{{ semverPrecedence "y" "1.0" }}`, `semverPrecedence: invalid version "y"`},
+		{`{{ semverPrecedence "1.0" "x" }}`, `semverPrecedence: invalid version "x"`},
+	} {
+		t.Run(test.template, func(t *testing.T) {
+			_, err := execute(t, test.template, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.message)
+		})
+	}
+}
+
+func TestHTMLFuncMap(t *testing.T) {
+	tmpl, err := htmltemplate.New("test").Funcs(HTMLFuncMap()).Parse(
+		`<p>{{ if semverCompare ">=1.20" . }}supported{{ end }} {{ semverBump "major" . }}</p>`)
+	require.NoError(t, err)
+	var sb strings.Builder
+	require.NoError(t, tmpl.Execute(&sb, "V1.21"))
+	assert.Equal(t, "<p>supported 2.0.0</p>", sb.String())
+}
@@ -0,0 +1,151 @@
+package solver
+
+import "github.com/launchdarkly/go-semver"
+
+// assignment is either a decision, which selects a specific version of a package, or a derivation, which
+// is a term that must be true given the decisions so far and the incompatibility that is its cause.
+type assignment struct {
+	term
+	decisionLevel int
+	cause         *incompatibility // nil for a decision
+}
+
+// partialSolution is the ordered list of assignments made so far.
+type partialSolution struct {
+	assignments []assignment
+	decisions   map[string]semver.Version
+	terms       map[string]term // the intersection of all assignments for each package
+	positive    []string        // packages with a positive term, in the order in which they became positive
+}
+
+func newPartialSolution() *partialSolution {
+	return &partialSolution{decisions: make(map[string]semver.Version), terms: make(map[string]term)}
+}
+
+func (ps *partialSolution) decisionLevel() int {
+	return len(ps.decisions)
+}
+
+func (ps *partialSolution) decide(pkg string, v semver.Version) {
+	ps.decisions[pkg] = v
+	ps.add(assignment{
+		term:          term{pkg: pkg, set: semver.NewVersionSet(semver.Exactly(v)), positive: true},
+		decisionLevel: ps.decisionLevel(),
+	})
+}
+
+func (ps *partialSolution) derive(t term, cause *incompatibility) {
+	ps.add(assignment{term: t, decisionLevel: ps.decisionLevel(), cause: cause})
+}
+
+func (ps *partialSolution) add(a assignment) {
+	ps.assignments = append(ps.assignments, a)
+	ps.accumulate(a.term)
+}
+
+func (ps *partialSolution) accumulate(t term) {
+	old, ok := ps.terms[t.pkg]
+	if ok {
+		t = old.intersect(t)
+	}
+	if t.positive && !(ok && old.positive) {
+		ps.positive = append(ps.positive, t.pkg)
+	}
+	ps.terms[t.pkg] = t
+}
+
+// backtrack removes all assignments whose decision level is greater than level.
+func (ps *partialSolution) backtrack(level int) {
+	n := 0
+	for n < len(ps.assignments) && ps.assignments[n].decisionLevel <= level {
+		n++
+	}
+	for _, a := range ps.assignments[n:] {
+		if a.cause == nil {
+			delete(ps.decisions, a.pkg)
+		}
+	}
+	ps.assignments = ps.assignments[:n]
+	ps.terms = make(map[string]term)
+	ps.positive = ps.positive[:0]
+	for _, a := range ps.assignments {
+		ps.accumulate(a.term)
+	}
+}
+
+// relation describes how the partial solution relates to a term.
+type relation int
+
+const (
+	inconclusive relation = iota
+	satisfied             // the term must be true
+	contradicted          // the term must be false
+)
+
+func (ps *partialSolution) relation(t term) relation {
+	current, ok := ps.terms[t.pkg]
+	switch {
+	case !ok:
+		return inconclusive
+	case current.satisfies(t):
+		return satisfied
+	case current.contradicts(t):
+		return contradicted
+	default:
+		return inconclusive
+	}
+}
+
+// satisfier returns the index of the earliest assignment such that the assignments up to and including it,
+// together with the extra term if any, satisfy every term of the incompatibility. It returns -1 if the
+// extra term alone is enough.
+func (ps *partialSolution) satisfier(inc *incompatibility, extra *term) int {
+	accumulated := make(map[string]term)
+	unsatisfied := 0
+	for _, t := range inc.terms {
+		if extra != nil && extra.pkg == t.pkg {
+			accumulated[t.pkg] = *extra
+			if extra.satisfies(t) {
+				continue
+			}
+		}
+		unsatisfied++
+	}
+	if unsatisfied == 0 {
+		return -1
+	}
+	for i, a := range ps.assignments {
+		t, ok := inc.termFor(a.pkg)
+		if !ok {
+			continue
+		}
+		old, hadOld := accumulated[a.pkg]
+		current := a.term
+		if hadOld {
+			if old.satisfies(t) {
+				continue
+			}
+			current = old.intersect(current)
+		}
+		accumulated[a.pkg] = current
+		if current.satisfies(t) {
+			unsatisfied--
+			if unsatisfied == 0 {
+				return i
+			}
+		}
+	}
+	panic("unsatisfied incompatibility") // COVERAGE: can only happen if this package has a bug
+}
+
+// undecided returns the packages that must be selected but do not yet have a version, in the order in
+// which they were first required.
+func (ps *partialSolution) undecided() []string {
+	var ret []string
+	for _, pkg := range ps.positive {
+		if _, ok := ps.decisions[pkg]; !ok {
+			ret = append(ret, pkg)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,173 @@
+package solver
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NoSolutionError is returned by Solve when the dependencies cannot be satisfied. Its message is a
+// step-by-step explanation of why, one line per step, such as:
+//
+//	Because every version of a depends on b >=2.0.0 and no versions of b match >=2.0.0, every version of a is forbidden.
+//	So, because app depends on a >=1.0.0 <2.0.0-0, version solving failed.
+//
+// Conclusions that are used more than once are numbered, so that later steps can refer to them.
+type NoSolutionError struct {
+	root            term
+	incompatibility *incompatibility
+}
+
+// Error returns the explanation of why there is no solution.
+func (e *NoSolutionError) Error() string {
+	r := reporter{
+		root:        e.root,
+		failure:     e.incompatibility,
+		derivations: make(map[*incompatibility]int),
+		lineNumbers: make(map[*incompatibility]int),
+	}
+	r.countDerivations(e.incompatibility)
+	if e.incompatibility.kind != causeConflict {
+		return "Because " + e.incompatibility.describe(e.root) + ", version solving failed."
+	}
+	r.visit(e.incompatibility, false)
+
+	var sb strings.Builder
+	for i, l := range r.lines {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		if l.number > 0 {
+			sb.WriteString("(" + strconv.Itoa(l.number) + ") ")
+		}
+		sb.WriteString(l.text)
+	}
+	return sb.String()
+}
+
+type reporter struct {
+	root        term
+	failure     *incompatibility
+	derivations map[*incompatibility]int // how many times each derived incompatibility is used
+	lineNumbers map[*incompatibility]int
+	lines       []reportLine
+}
+
+type reportLine struct {
+	text   string
+	number int // zero if the line is not numbered
+}
+
+func (r *reporter) countDerivations(inc *incompatibility) {
+	r.derivations[inc]++
+	if r.derivations[inc] == 1 && inc.kind == causeConflict {
+		r.countDerivations(inc.cause)
+		r.countDerivations(inc.other)
+	}
+}
+
+// visit writes the lines that explain a derived incompatibility, following the reporting algorithm of
+// the PubGrub documentation.
+func (r *reporter) visit(inc *incompatibility, conclusion bool) {
+	numbered := conclusion || r.derivations[inc] > 1
+	conjunction := "And"
+	if conclusion || inc == r.failure {
+		conjunction = "So,"
+	}
+	text := r.describe(inc)
+	cause, other := inc.cause, inc.other
+
+	switch {
+	case cause.kind == causeConflict && other.kind == causeConflict:
+		causeLine, causeNumbered := r.lineNumbers[cause]
+		otherLine, otherNumbered := r.lineNumbers[other]
+		switch {
+		case causeNumbered && otherNumbered:
+			r.write(inc, "Because "+r.describe(cause)+" ("+strconv.Itoa(causeLine)+") and "+r.describe(other)+
+				" ("+strconv.Itoa(otherLine)+"), "+text+".", numbered)
+		case causeNumbered || otherNumbered:
+			withLine, withoutLine, line := cause, other, causeLine
+			if otherNumbered {
+				withLine, withoutLine, line = other, cause, otherLine
+			}
+			r.visit(withoutLine, false)
+			r.write(inc, conjunction+" because "+r.describe(withLine)+" ("+strconv.Itoa(line)+"), "+text+".",
+				numbered)
+		case isSingleLine(cause) || isSingleLine(other):
+			first, second := cause, other
+			if isSingleLine(cause) {
+				first, second = other, cause
+			}
+			r.visit(first, false)
+			r.visit(second, false)
+			r.write(inc, "Thus, "+text+".", numbered)
+		default:
+			r.visit(cause, true)
+			r.lines = append(r.lines, reportLine{})
+			r.visit(other, false)
+			r.write(inc, conjunction+" because "+r.describe(cause)+" ("+strconv.Itoa(r.lineNumbers[cause])+"), "+
+				text+".", numbered)
+		}
+
+	case cause.kind == causeConflict || other.kind == causeConflict:
+		derived, external := cause, other
+		if other.kind == causeConflict {
+			derived, external = other, cause
+		}
+		if line, ok := r.lineNumbers[derived]; ok {
+			r.write(inc, "Because "+r.describe(external)+" and "+r.describe(derived)+" ("+strconv.Itoa(line)+"), "+
+				text+".", numbered)
+		} else if r.isCollapsible(derived) {
+			collapsedDerived, collapsedExternal := derived.cause, derived.other
+			if collapsedDerived.kind != causeConflict {
+				collapsedDerived, collapsedExternal = collapsedExternal, collapsedDerived
+			}
+			r.visit(collapsedDerived, false)
+			r.write(inc, conjunction+" because "+r.describe(collapsedExternal)+" and "+r.describe(external)+", "+
+				text+".", numbered)
+		} else {
+			r.visit(derived, false)
+			r.write(inc, conjunction+" because "+r.describe(external)+", "+text+".", numbered)
+		}
+
+	default:
+		r.write(inc, "Because "+r.describe(cause)+" and "+r.describe(other)+", "+text+".", numbered)
+	}
+}
+
+func (r *reporter) write(inc *incompatibility, text string, numbered bool) {
+	line := reportLine{text: text}
+	if numbered {
+		line.number = len(r.lineNumbers) + 1
+		r.lineNumbers[inc] = line.number
+	}
+	r.lines = append(r.lines, line)
+}
+
+func (r *reporter) describe(inc *incompatibility) string {
+	return inc.describe(r.root)
+}
+
+// isCollapsible returns true if a derived incompatibility can be explained as part of the line that
+// uses it, because it is used only once and was derived from one external and one derived incompatibility.
+func (r *reporter) isCollapsible(inc *incompatibility) bool {
+	if r.derivations[inc] > 1 {
+		return false
+	}
+	if inc.cause.kind == causeConflict && inc.other.kind == causeConflict {
+		return false
+	}
+	if inc.cause.kind != causeConflict && inc.other.kind != causeConflict {
+		return false
+	}
+	derived := inc.cause
+	if derived.kind != causeConflict {
+		derived = inc.other
+	}
+	_, numbered := r.lineNumbers[derived]
+	return !numbered
+}
+
+// isSingleLine returns true if a derived incompatibility was derived from two external ones.
+func isSingleLine(inc *incompatibility) bool {
+	return inc.cause.kind != causeConflict && inc.other.kind != causeConflict
+}
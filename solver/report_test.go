@@ -0,0 +1,146 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests build derivation graphs by hand, rather than by solving, so that every shape of graph that
+// the reporting algorithm distinguishes can be tested.
+
+func versionSet(t *testing.T, rangeString string) semver.VersionSet {
+	rng, err := semver.ParseRange(rangeString)
+	require.NoError(t, err)
+	return rng.VersionSet()
+}
+
+func positive(t *testing.T, pkg, rangeString string) term {
+	return term{pkg: pkg, set: versionSet(t, rangeString), positive: true}
+}
+
+func negative(t *testing.T, pkg, rangeString string) term {
+	return term{pkg: pkg, set: versionSet(t, rangeString)}
+}
+
+func rootTerm(t *testing.T) term {
+	return positive(t, "root", "1.0.0")
+}
+
+func dependency(t *testing.T, pkg, rangeString, dep, depRangeString string) *incompatibility {
+	return newIncompatibility([]term{positive(t, pkg, rangeString), negative(t, dep, depRangeString)},
+		causeDependency, nil, nil)
+}
+
+func noVersions(t *testing.T, pkg, rangeString string) *incompatibility {
+	return newIncompatibility([]term{positive(t, pkg, rangeString)}, causeNoVersions, nil, nil)
+}
+
+func derived(cause, other *incompatibility, terms ...term) *incompatibility {
+	return newIncompatibility(terms, causeConflict, cause, other)
+}
+
+func report(t *testing.T, failure *incompatibility) string {
+	return (&NoSolutionError{root: rootTerm(t), incompatibility: failure}).Error()
+}
+
+func TestDescribe(t *testing.T) {
+	for _, test := range []struct {
+		inc      *incompatibility
+		expected string
+	}{
+		{newIncompatibility([]term{rootTerm(t)}, causeRoot, nil, nil), "root is selected"},
+		{noVersions(t, "a", ">=2.0.0"), "no versions of a match >=2.0.0"},
+		{dependency(t, "a", "*", "b", "^1.0.0"), "every version of a depends on b >=1.0.0 <2.0.0-0"},
+		{newIncompatibility([]term{negative(t, "b", "1.0.0"), positive(t, "a", "<2.0.0")}, causeDependency, nil, nil),
+			"a <2.0.0 depends on b 1.0.0"},
+		{dependency(t, "root", "1.0.0", "a", "*"), "root depends on every version of a"},
+		{derived(nil, nil), "version solving failed"},
+		{derived(nil, nil, rootTerm(t)), "version solving failed"},
+		{derived(nil, nil, negative(t, "a", "^1.0.0")), "a >=1.0.0 <2.0.0-0 is required"},
+		{derived(nil, nil, negative(t, "a", "1.0.0"), negative(t, "b", "*")),
+			"either a 1.0.0 or every version of b is required"},
+		{derived(nil, nil, positive(t, "a", "*")), "every version of a is forbidden"},
+		{derived(nil, nil, positive(t, "a", "1.0.0"), positive(t, "b", ">=2.0.0")),
+			"a 1.0.0 is incompatible with b >=2.0.0"},
+		{derived(nil, nil, positive(t, "a", "1.0.0"), positive(t, "b", "2.0.0"), positive(t, "c", "3.0.0")),
+			"one of a 1.0.0, b 2.0.0, c 3.0.0 must be false"},
+		{derived(nil, nil, positive(t, "a", "1.0.0"), positive(t, "b", "2.0.0"), negative(t, "c", "3.0.0"),
+			negative(t, "d", "4.0.0")), "a 1.0.0 and b 2.0.0 requires c 3.0.0 or d 4.0.0"},
+	} {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.inc.describe(rootTerm(t)))
+		})
+	}
+}
+
+func TestReportExternalFailure(t *testing.T) {
+	assert.Equal(t, "Because no versions of root match =1.0.0, version solving failed.",
+		report(t, noVersions(t, "root", "1.0.0")))
+}
+
+func TestReportRefersToNumberedLines(t *testing.T) {
+	// a and b are each forbidden for an external reason, and both of those conclusions are used twice
+	a := derived(dependency(t, "a", "*", "c", "^1.0.0"), noVersions(t, "c", "^1.0.0"), positive(t, "a", "*"))
+	b := derived(dependency(t, "b", "*", "d", "^1.0.0"), noVersions(t, "d", "^1.0.0"), positive(t, "b", "*"))
+	e := derived(a, b, positive(t, "e", "*"))
+	f := derived(a, b, positive(t, "f", "*"))
+	assert.Equal(t,
+		"(1) Because every version of b depends on d >=1.0.0 <2.0.0-0 and no versions of d match "+
+			">=1.0.0 <2.0.0-0, every version of b is forbidden.\n"+
+			"(2) Because every version of a depends on c >=1.0.0 <2.0.0-0 and no versions of c match "+
+			">=1.0.0 <2.0.0-0, every version of a is forbidden.\n"+
+			"(3) Thus, every version of e is forbidden.\n"+
+			"\n"+
+			"Because every version of a is forbidden (2) and every version of b is forbidden (1), "+
+			"every version of f is forbidden.\n"+
+			"So, because every version of e is forbidden (3), version solving failed.",
+		report(t, derived(e, f)))
+}
+
+func TestReportRefersToOneNumberedLine(t *testing.T) {
+	// a is forbidden for an external reason, and that conclusion is used twice
+	a := derived(dependency(t, "a", "*", "c", "^1.0.0"), noVersions(t, "c", "^1.0.0"), positive(t, "a", "*"))
+	b := derived(dependency(t, "b", "*", "d", "^1.0.0"), noVersions(t, "d", "^1.0.0"), positive(t, "b", "*"))
+	x := derived(a, dependency(t, "x", "*", "a", "*"), positive(t, "x", "*"))
+	y := derived(b, a, positive(t, "y", "*"))
+	assert.Equal(t,
+		"(1) Because every version of a depends on c >=1.0.0 <2.0.0-0 and no versions of c match "+
+			">=1.0.0 <2.0.0-0, every version of a is forbidden.\n"+
+			"(2) So, because every version of x depends on every version of a, every version of x is forbidden.\n"+
+			"\n"+
+			"Because every version of b depends on d >=1.0.0 <2.0.0-0 and no versions of d match "+
+			">=1.0.0 <2.0.0-0, every version of b is forbidden.\n"+
+			"And because every version of a is forbidden (1), every version of y is forbidden.\n"+
+			"So, because every version of x is forbidden (2), version solving failed.",
+		report(t, derived(x, y)))
+}
+
+func TestReportRefersToNumberedLineWithExternalCause(t *testing.T) {
+	a := derived(dependency(t, "a", "*", "c", "^1.0.0"), noVersions(t, "c", "^1.0.0"), positive(t, "a", "*"))
+	x := derived(a, dependency(t, "x", "*", "a", "*"), positive(t, "x", "*"))
+	y := derived(dependency(t, "y", "*", "a", "*"), a, positive(t, "y", "*"))
+	assert.Equal(t,
+		"(1) Because every version of a depends on c >=1.0.0 <2.0.0-0 and no versions of c match "+
+			">=1.0.0 <2.0.0-0, every version of a is forbidden.\n"+
+			"(2) So, because every version of x depends on every version of a, every version of x is forbidden.\n"+
+			"\n"+
+			"Because every version of y depends on every version of a and every version of a is forbidden (1), "+
+			"every version of y is forbidden.\n"+
+			"So, because every version of x is forbidden (2), version solving failed.",
+		report(t, derived(x, y)))
+}
+
+func TestReportCollapsesDerivationUsedOnce(t *testing.T) {
+	a := derived(dependency(t, "a", "*", "c", "^1.0.0"), noVersions(t, "c", "^1.0.0"), positive(t, "a", "*"))
+	b := derived(dependency(t, "b", "*", "a", "*"), a, positive(t, "b", "*"))
+	assert.Equal(t,
+		"Because every version of a depends on c >=1.0.0 <2.0.0-0 and no versions of c match "+
+			">=1.0.0 <2.0.0-0, every version of a is forbidden.\n"+
+			"So, because every version of b depends on every version of a and root depends on every version "+
+			"of b, version solving failed.",
+		report(t, derived(b, dependency(t, "root", "1.0.0", "b", "*"))))
+}
@@ -0,0 +1,280 @@
+// Package solver resolves dependency graphs of versioned packages with the PubGrub algorithm, as
+// described at https://github.com/dart-lang/pub/blob/master/doc/solver.md.
+//
+// Given a root package, and a Source that lists the available versions of each package and the
+// dependencies of each version, Solve either selects one version of every package that is needed, such
+// that all dependencies are satisfied, or returns a NoSolutionError that explains in plain English why
+// that is impossible. Dependencies are expressed as semver.VersionSet values, since the algorithm relies
+// on their set algebra.
+package solver
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// Source provides information about the available packages to Solve.
+type Source interface {
+	// Versions returns the available versions of a package, in any order. It returns an empty list for an
+	// unknown package.
+	Versions(pkg string) ([]semver.Version, error)
+
+	// Dependencies returns the dependencies of a version of a package, as a map from the name of each
+	// package that it depends on to the set of versions of that package that it accepts.
+	Dependencies(pkg string, version semver.Version) (map[string]semver.VersionSet, error)
+}
+
+type solver struct {
+	source            Source
+	root              string
+	rootVersion       semver.Version
+	incompatibilities map[string][]*incompatibility
+	solution          *partialSolution
+	versions          map[string][]semver.Version // sorted in descending order
+}
+
+// Solve selects a version of every package that the specified version of the root package depends on,
+// directly or indirectly, such that every dependency of every selected version is satisfied. It returns
+// a map from package names to versions, which includes the root package.
+//
+// When there is a choice, Solve prefers higher versions, and it selects a prerelease version of a
+// package only if no other version is acceptable. A dependency of a package on itself is ignored. The
+// Source does not need to list the root version as one of the versions of the root package.
+//
+// If there is no solution, it returns a *NoSolutionError. If the Source returns an error, Solve stops and
+// returns that error, wrapped with the name of the package.
+func Solve(source Source, root string, version semver.Version) (map[string]semver.Version, error) {
+	s := &solver{
+		source:            source,
+		root:              root,
+		rootVersion:       version,
+		incompatibilities: make(map[string][]*incompatibility),
+		solution:          newPartialSolution(),
+		versions:          map[string][]semver.Version{root: {version}},
+	}
+	s.addIncompatibility(newIncompatibility(
+		[]term{{pkg: root, set: semver.NewVersionSet(semver.Exactly(version))}}, causeRoot, nil, nil))
+
+	next := root
+	for {
+		if err := s.propagate(next); err != nil {
+			return nil, err
+		}
+		var done bool
+		var err error
+		if next, done, err = s.decide(); err != nil {
+			return nil, err
+		}
+		if done {
+			ret := make(map[string]semver.Version, len(s.solution.decisions))
+			for pkg, v := range s.solution.decisions {
+				ret[pkg] = v
+			}
+			return ret, nil
+		}
+	}
+}
+
+func (s *solver) addIncompatibility(inc *incompatibility) {
+	for _, t := range inc.terms {
+		s.incompatibilities[t.pkg] = append(s.incompatibilities[t.pkg], inc)
+	}
+}
+
+// propagate derives every term that follows from the incompatibilities, starting with those that refer to
+// the specified package, and resolves any conflicts that it finds.
+func (s *solver) propagate(pkg string) error {
+	changed := []string{pkg}
+	for len(changed) > 0 {
+		pkg := changed[len(changed)-1]
+		changed = changed[:len(changed)-1]
+		incs := s.incompatibilities[pkg]
+		for i := len(incs) - 1; i >= 0; i-- {
+			derived, conflict := s.propagateIncompatibility(incs[i])
+			if conflict {
+				rootCause, err := s.resolveConflict(incs[i])
+				if err != nil {
+					return err
+				}
+				derived, _ = s.propagateIncompatibility(rootCause)
+				changed = []string{derived}
+				break
+			}
+			if derived != "" {
+				changed = append(changed, derived)
+			}
+		}
+	}
+	return nil
+}
+
+// propagateIncompatibility checks whether the partial solution satisfies all but one of the terms of the
+// incompatibility, and if so, adds the inverse of the remaining term to the partial solution and returns
+// its package. It returns true if the partial solution satisfies every term, which is a conflict.
+func (s *solver) propagateIncompatibility(inc *incompatibility) (string, bool) {
+	var unsatisfied *term
+	for i, t := range inc.terms {
+		switch s.solution.relation(t) {
+		case satisfied:
+			continue
+		case contradicted:
+			return "", false
+		default:
+			if unsatisfied != nil {
+				return "", false
+			}
+			unsatisfied = &inc.terms[i]
+		}
+	}
+	if unsatisfied == nil {
+		return "", true
+	}
+	s.solution.derive(unsatisfied.negate(), inc)
+	return unsatisfied.pkg, false
+}
+
+// resolveConflict derives new incompatibilities from one that is satisfied by the partial solution, until
+// it finds one that allows backtracking to an earlier decision level. It returns that incompatibility, or
+// a *NoSolutionError if it proves that there is no solution.
+func (s *solver) resolveConflict(inc *incompatibility) (*incompatibility, error) {
+	original := inc
+	for !inc.isFailure(s.root) {
+		satisfier := s.solution.assignments[s.solution.satisfier(inc, nil)]
+		satisfierTerm, _ := inc.termFor(satisfier.pkg)
+
+		previousLevel := 1
+		if previous := s.solution.satisfier(inc, &satisfier.term); previous >= 0 {
+			previousLevel = max(previousLevel, s.solution.assignments[previous].decisionLevel)
+		}
+
+		if satisfier.cause == nil || previousLevel != satisfier.decisionLevel {
+			if inc != original {
+				s.addIncompatibility(inc)
+			}
+			s.solution.backtrack(previousLevel)
+			return inc, nil
+		}
+
+		var terms []term
+		for _, t := range inc.terms {
+			if t.pkg != satisfier.pkg {
+				terms = append(terms, t)
+			}
+		}
+		for _, t := range satisfier.cause.terms {
+			if t.pkg != satisfier.pkg {
+				terms = append(terms, t)
+			}
+		}
+		if !satisfier.term.satisfies(satisfierTerm) {
+			terms = append(terms, satisfier.term.intersect(satisfierTerm.negate()).negate())
+		}
+		inc = newIncompatibility(terms, causeConflict, inc, satisfier.cause)
+	}
+	root := term{pkg: s.root, set: semver.NewVersionSet(semver.Exactly(s.rootVersion)), positive: true}
+	return nil, &NoSolutionError{root: root, incompatibility: inc}
+}
+
+// decide selects a version of a package that must be selected but does not have a version yet, and
+// returns the package. It returns true if there is no such package, which means that the partial solution
+// is complete.
+func (s *solver) decide() (string, bool, error) {
+	var pkg string
+	var candidates []semver.Version
+	for _, p := range s.solution.undecided() {
+		versions, err := s.versionsOf(p)
+		if err != nil {
+			return "", false, err
+		}
+		var matching []semver.Version
+		for _, v := range versions {
+			if s.solution.terms[p].set.Contains(v) {
+				matching = append(matching, v)
+			}
+		}
+		// packages with fewer choices are decided first, since they are the most likely to conflict
+		if pkg == "" || len(matching) < len(candidates) {
+			pkg, candidates = p, matching
+		}
+	}
+	if pkg == "" {
+		return "", true, nil
+	}
+	if len(candidates) == 0 {
+		s.addIncompatibility(newIncompatibility([]term{s.solution.terms[pkg]}, causeNoVersions, nil, nil))
+		return pkg, false, nil
+	}
+
+	version := candidates[0]
+	for _, v := range candidates {
+		if v.GetPrerelease() == "" {
+			version = v
+			break
+		}
+	}
+	deps, err := s.source.Dependencies(pkg, version)
+	if err != nil {
+		return "", false, fmt.Errorf("dependencies of %s %s: %w", pkg, version, err)
+	}
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		if name != pkg {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	// If the dependencies conflict with the partial solution, the version is not selected; instead, unit
+	// propagation will rule it out.
+	conflict := false
+	selected := term{pkg: pkg, set: s.surrounding(pkg, version), positive: true}
+	for _, name := range names {
+		inc := newIncompatibility([]term{selected, {pkg: name, set: deps[name]}}, causeDependency, nil, nil)
+		s.addIncompatibility(inc)
+		if s.solution.relation(inc.terms[1]) == satisfied {
+			conflict = true
+		}
+	}
+	if !conflict {
+		s.solution.decide(pkg, version)
+	}
+	return pkg, false, nil
+}
+
+// surrounding returns the set of versions of a package that are higher than the next lower available
+// version and lower than the next higher one. It is used instead of the exact version in the
+// incompatibilities that describe the dependencies of that version, since it is equivalent given the
+// available versions, and it allows conflict resolution to produce simpler explanations: for instance,
+// if there is only one version of foo, "every version of foo depends on bar" rather than "foo 1.0.0
+// depends on bar" and "no versions of foo match >1.0.0".
+func (s *solver) surrounding(pkg string, version semver.Version) semver.VersionSet {
+	var constraints []semver.Constraint[semver.Version]
+	for _, v := range s.versions[pkg] { // in descending order
+		d := v.ComparePrecedence(version)
+		if d > 0 {
+			constraints = append(constraints[:0], semver.LessThan(v))
+		} else if d < 0 {
+			constraints = append(constraints, semver.GreaterThan(v))
+			break
+		}
+	}
+	return semver.NewVersionSet(constraints...)
+}
+
+// versionsOf returns the available versions of a package, in descending order.
+func (s *solver) versionsOf(pkg string) ([]semver.Version, error) {
+	if versions, ok := s.versions[pkg]; ok {
+		return versions, nil
+	}
+	versions, err := s.source.Versions(pkg)
+	if err != nil {
+		return nil, fmt.Errorf("versions of %s: %w", pkg, err)
+	}
+	versions = slices.Clone(versions)
+	semver.Sort(versions)
+	slices.Reverse(versions)
+	s.versions[pkg] = versions
+	return versions, nil
+}
@@ -0,0 +1,235 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/launchdarkly/go-semver"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registry is an in-memory Source: a map from package names to versions to dependencies, where each
+// dependency is a range expression such as "^1.0.0".
+type registry map[string]map[string]map[string]string
+
+func (r registry) Versions(pkg string) ([]semver.Version, error) {
+	var ret []semver.Version
+	for s := range r[pkg] {
+		v, err := semver.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+	return ret, nil
+}
+
+func (r registry) Dependencies(pkg string, version semver.Version) (map[string]semver.VersionSet, error) {
+	ret := make(map[string]semver.VersionSet)
+	for name, rangeString := range r[pkg][version.String()] {
+		rng, err := semver.ParseRange(rangeString)
+		if err != nil {
+			return nil, err
+		}
+		ret[name] = rng.VersionSet()
+	}
+	return ret, nil
+}
+
+func solve(t *testing.T, r registry) (map[string]string, error) {
+	rootVersion, err := semver.Parse("1.0.0")
+	require.NoError(t, err)
+	solution, err := Solve(r, "root", rootVersion)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]string, len(solution))
+	for pkg, v := range solution {
+		ret[pkg] = v.String()
+	}
+	return ret, nil
+}
+
+func requireSolution(t *testing.T, r registry, expected map[string]string) {
+	solution, err := solve(t, r)
+	require.NoError(t, err)
+	assert.Equal(t, expected, solution)
+}
+
+func requireFailure(t *testing.T, r registry, expected string) {
+	_, err := solve(t, r)
+	require.Error(t, err)
+	var noSolution *NoSolutionError
+	require.True(t, errors.As(err, &noSolution))
+	assert.Equal(t, expected, err.Error())
+}
+
+// The following scenarios are the examples in the PubGrub documentation.
+
+func TestNoConflicts(t *testing.T) {
+	requireSolution(t, registry{
+		"root": {"1.0.0": {"foo": "^1.0.0"}},
+		"foo":  {"1.0.0": {"bar": "^1.0.0"}},
+		"bar":  {"1.0.0": {}, "2.0.0": {}},
+	}, map[string]string{"root": "1.0.0", "foo": "1.0.0", "bar": "1.0.0"})
+}
+
+func TestAvoidingConflictDuringDecisionMaking(t *testing.T) {
+	requireSolution(t, registry{
+		"root": {"1.0.0": {"foo": "^1.0.0", "bar": "^1.0.0"}},
+		"foo":  {"1.1.0": {"bar": "^2.0.0"}, "1.0.0": {}},
+		"bar":  {"1.0.0": {}, "1.1.0": {}, "2.0.0": {}},
+	}, map[string]string{"root": "1.0.0", "foo": "1.0.0", "bar": "1.1.0"})
+}
+
+func TestPerformingConflictResolution(t *testing.T) {
+	requireSolution(t, registry{
+		"root": {"1.0.0": {"foo": ">=1.0.0"}},
+		"foo":  {"2.0.0": {"bar": "^1.0.0"}, "1.0.0": {}},
+		"bar":  {"1.0.0": {"foo": "^1.0.0"}},
+	}, map[string]string{"root": "1.0.0", "foo": "1.0.0"})
+}
+
+func TestConflictResolutionWithPartialSatisfier(t *testing.T) {
+	requireSolution(t, registry{
+		"root":   {"1.0.0": {"foo": "^1.0.0", "target": "^2.0.0"}},
+		"foo":    {"1.1.0": {"left": "^1.0.0", "right": "^1.0.0"}, "1.0.0": {}},
+		"left":   {"1.0.0": {"shared": ">=1.0.0"}},
+		"right":  {"1.0.0": {"shared": "<2.0.0"}},
+		"shared": {"2.0.0": {}, "1.0.0": {"target": "^1.0.0"}},
+		"target": {"2.0.0": {}, "1.0.0": {}},
+	}, map[string]string{"root": "1.0.0", "foo": "1.0.0", "target": "2.0.0"})
+}
+
+func TestLinearErrorReporting(t *testing.T) {
+	requireFailure(t, registry{
+		"root": {"1.0.0": {"foo": "^1.0.0", "baz": "^1.0.0"}},
+		"foo":  {"1.0.0": {"bar": "^2.0.0"}},
+		"bar":  {"2.0.0": {"baz": "^3.0.0"}},
+		"baz":  {"1.0.0": {}, "3.0.0": {}},
+	}, "Because every version of foo depends on bar >=2.0.0 <3.0.0-0 and every version of bar depends on "+
+		"baz >=3.0.0 <4.0.0-0, every version of foo requires baz >=3.0.0 <4.0.0-0.\n"+
+		"So, because root depends on baz >=1.0.0 <2.0.0-0 and root depends on foo >=1.0.0 <2.0.0-0, "+
+		"version solving failed.")
+}
+
+func TestBranchingErrorReporting(t *testing.T) {
+	requireFailure(t, registry{
+		"root": {"1.0.0": {"foo": "^1.0.0"}},
+		"foo":  {"1.0.0": {"a": "^1.0.0", "b": "^1.0.0"}, "1.1.0": {"x": "^1.0.0", "y": "^1.0.0"}},
+		"a":    {"1.0.0": {"b": "^2.0.0"}},
+		"b":    {"1.0.0": {}, "2.0.0": {}},
+		"x":    {"1.0.0": {"y": "^2.0.0"}},
+		"y":    {"1.0.0": {}, "2.0.0": {}},
+	}, "Because every version of a depends on b >=2.0.0 <3.0.0-0 and foo <1.1.0 depends on a >=1.0.0 <2.0.0-0, "+
+		"foo <1.1.0 requires b >=2.0.0 <3.0.0-0.\n"+
+		"(1) So, because foo <1.1.0 depends on b >=1.0.0 <2.0.0-0, foo <1.1.0 is forbidden.\n"+
+		"\n"+
+		"Because every version of x depends on y >=2.0.0 <3.0.0-0 and foo >1.0.0 depends on x >=1.0.0 <2.0.0-0, "+
+		"foo >1.0.0 requires y >=2.0.0 <3.0.0-0.\n"+
+		"And because foo >1.0.0 depends on y >=1.0.0 <2.0.0-0, foo >1.0.0 is forbidden.\n"+
+		"And because foo <1.1.0 is forbidden (1), every version of foo is forbidden.\n"+
+		"So, because root depends on foo >=1.0.0 <2.0.0-0, version solving failed.")
+}
+
+func TestMissingPackage(t *testing.T) {
+	requireFailure(t, registry{
+		"root": {"1.0.0": {"foo": "^1.0.0"}},
+	}, "Because no versions of foo match >=1.0.0 <2.0.0-0 and root depends on foo >=1.0.0 <2.0.0-0, "+
+		"version solving failed.")
+}
+
+func TestNoMatchingVersion(t *testing.T) {
+	requireFailure(t, registry{
+		"root": {"1.0.0": {"foo": "^1.0.0"}},
+		"foo":  {"1.0.0": {"bar": ">=2.0.0"}},
+		"bar":  {"1.0.0": {}, "1.5.0": {}},
+	}, "Because every version of foo depends on bar >=2.0.0 and no versions of bar match >=2.0.0, "+
+		"every version of foo is forbidden.\n"+
+		"So, because root depends on foo >=1.0.0 <2.0.0-0, version solving failed.")
+}
+
+func TestPrefersHigherVersionsAndAvoidsPrereleases(t *testing.T) {
+	r := registry{
+		"root": {"1.0.0": {"foo": "*"}},
+		"foo":  {"1.0.0": {}, "1.2.0": {}, "1.1.0": {}, "2.0.0-beta": {}},
+	}
+	requireSolution(t, r, map[string]string{"root": "1.0.0", "foo": "1.2.0"})
+
+	r["root"]["1.0.0"]["foo"] = ">=2.0.0-0"
+	requireSolution(t, r, map[string]string{"root": "1.0.0", "foo": "2.0.0-beta"})
+}
+
+func TestBacktracksAcrossSeveralDecisions(t *testing.T) {
+	requireSolution(t, registry{
+		"root": {"1.0.0": {"a": "*", "b": "*"}},
+		"a":    {"1.0.0": {"c": "1.0.0"}, "2.0.0": {"c": "2.0.0"}, "3.0.0": {"c": "3.0.0"}},
+		"b":    {"1.0.0": {"c": "1.0.0"}, "2.0.0": {"d": "2.0.0"}},
+		"c":    {"1.0.0": {}, "2.0.0": {}, "3.0.0": {"d": "1.0.0"}},
+		"d":    {"1.0.0": {}, "2.0.0": {"c": "<3.0.0"}},
+	}, map[string]string{"root": "1.0.0", "a": "2.0.0", "b": "2.0.0", "c": "2.0.0", "d": "2.0.0"})
+}
+
+func TestIgnoresDependencyOnItself(t *testing.T) {
+	requireSolution(t, registry{
+		"root": {"1.0.0": {"root": "2.0.0", "foo": "*"}},
+		"foo":  {"1.0.0": {"foo": "2.0.0"}},
+	}, map[string]string{"root": "1.0.0", "foo": "1.0.0"})
+}
+
+func TestCircularDependencies(t *testing.T) {
+	requireSolution(t, registry{
+		"root": {"1.0.0": {"foo": "*"}},
+		"foo":  {"1.0.0": {"bar": "*"}},
+		"bar":  {"1.0.0": {"foo": "1.0.0", "root": "1.0.0"}},
+	}, map[string]string{"root": "1.0.0", "foo": "1.0.0", "bar": "1.0.0"})
+}
+
+func TestConflictWithRootVersion(t *testing.T) {
+	requireFailure(t, registry{
+		"root": {"1.0.0": {"foo": "*"}},
+		"foo":  {"1.0.0": {"root": "2.0.0"}},
+	}, "Because every version of foo depends on root 2.0.0 and root depends on every version of foo, "+
+		"version solving failed.")
+}
+
+type failingSource struct {
+	registry
+	failOn string
+}
+
+var errSource = errors.New("source failed")
+
+func (s failingSource) Versions(pkg string) ([]semver.Version, error) {
+	if pkg == s.failOn {
+		return nil, errSource
+	}
+	return s.registry.Versions(pkg)
+}
+
+func (s failingSource) Dependencies(pkg string, version semver.Version) (map[string]semver.VersionSet, error) {
+	if pkg == s.failOn+"-deps" {
+		return nil, errSource
+	}
+	return s.registry.Dependencies(pkg, version)
+}
+
+func TestSourceErrors(t *testing.T) {
+	r := registry{
+		"root":     {"1.0.0": {"foo": "*", "bar-deps": "*"}},
+		"foo":      {"1.0.0": {}},
+		"bar-deps": {"1.0.0": {}},
+	}
+	rootVersion, err := semver.Parse("1.0.0")
+	require.NoError(t, err)
+
+	_, err = Solve(failingSource{r, "foo"}, "root", rootVersion)
+	assert.True(t, errors.Is(err, errSource))
+	assert.Equal(t, "versions of foo: source failed", err.Error())
+
+	_, err = Solve(failingSource{r, "bar"}, "root", rootVersion)
+	assert.True(t, errors.Is(err, errSource))
+	assert.Equal(t, "dependencies of bar-deps 1.0.0: source failed", err.Error())
+}
@@ -0,0 +1,165 @@
+package solver
+
+import (
+	"strings"
+
+	"github.com/launchdarkly/go-semver"
+)
+
+// term is a statement about a package: a positive term "foo S" means that foo is selected with a version
+// in S, and a negative term "not foo S" means that foo is either not selected, or selected with a version
+// that is not in S.
+type term struct {
+	pkg      string
+	set      semver.VersionSet
+	positive bool
+}
+
+func (t term) negate() term {
+	return term{pkg: t.pkg, set: t.set, positive: !t.positive}
+}
+
+// intersect returns a term that is true only when both t and other are true. Both must refer to the same
+// package.
+func (t term) intersect(other term) term {
+	switch {
+	case t.positive && other.positive:
+		return term{pkg: t.pkg, set: t.set.Intersect(other.set), positive: true}
+	case t.positive:
+		return term{pkg: t.pkg, set: t.set.Difference(other.set), positive: true}
+	case other.positive:
+		return term{pkg: t.pkg, set: other.set.Difference(t.set), positive: true}
+	default:
+		return term{pkg: t.pkg, set: t.set.Union(other.set)}
+	}
+}
+
+func (t term) equal(other term) bool {
+	return t.positive == other.positive && t.set.Equal(other.set)
+}
+
+// satisfies returns true if other is true whenever t is true.
+func (t term) satisfies(other term) bool {
+	return t.intersect(other).equal(t)
+}
+
+// contradicts returns true if t and other can never both be true.
+func (t term) contradicts(other term) bool {
+	i := t.intersect(other)
+	return i.positive && i.set.IsEmpty()
+}
+
+// describe returns a description of the package and version set, such as "foo >=1.0.0 <2.0.0", ignoring
+// whether the term is positive. The root term is the term that selects the root version of the root
+// package; the version is omitted if it is the root version.
+func (t term) describe(root term) string {
+	switch {
+	case t.pkg == root.pkg && (t.set.IsAll() || t.set.Equal(root.set)):
+		return t.pkg
+	case t.set.IsAll():
+		return "every version of " + t.pkg
+	}
+	if v, ok := t.set.Single(); ok {
+		return t.pkg + " " + v.String()
+	}
+	return t.pkg + " " + t.set.String()
+}
+
+// incompatibility is a set of terms that cannot all be true; at most one term refers to each package.
+// Every incompatibility records why it is known to be true: because of one of the external facts, or
+// because it was derived from two other incompatibilities during conflict resolution.
+type incompatibility struct {
+	terms []term
+	kind  causeKind
+
+	// for causeConflict, the two incompatibilities that this one was derived from
+	cause, other *incompatibility
+}
+
+type causeKind int
+
+const (
+	causeRoot       causeKind = iota // the root package must be selected
+	causeNoVersions                  // no versions of a package match a term
+	causeDependency                  // a version of a package depends on another package
+	causeConflict                    // derived during conflict resolution
+)
+
+// newIncompatibility creates an incompatibility, combining any terms that refer to the same package.
+func newIncompatibility(terms []term, kind causeKind, cause, other *incompatibility) *incompatibility {
+	ret := &incompatibility{kind: kind, cause: cause, other: other}
+	for _, t := range terms {
+		found := false
+		for i := range ret.terms {
+			if ret.terms[i].pkg == t.pkg {
+				ret.terms[i] = ret.terms[i].intersect(t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			ret.terms = append(ret.terms, t)
+		}
+	}
+	return ret
+}
+
+func (inc *incompatibility) termFor(pkg string) (term, bool) {
+	for _, t := range inc.terms {
+		if t.pkg == pkg {
+			return t, true
+		}
+	}
+	return term{}, false
+}
+
+// isFailure returns true if the incompatibility means that there is no solution: either it has no terms,
+// or it says that the root package cannot be selected.
+func (inc *incompatibility) isFailure(root string) bool {
+	return len(inc.terms) == 0 || (len(inc.terms) == 1 && inc.terms[0].positive && inc.terms[0].pkg == root)
+}
+
+// describe returns a description of the incompatibility as an English clause, such as "foo >1.0.0 depends
+// on bar >=2.0.0" or "no versions of baz match >=3.0.0".
+func (inc *incompatibility) describe(root term) string {
+	switch inc.kind {
+	case causeRoot:
+		return root.pkg + " is selected"
+	case causeNoVersions:
+		return "no versions of " + inc.terms[0].pkg + " match " + inc.terms[0].set.String()
+	case causeDependency:
+		if len(inc.terms) == 2 {
+			depender, dependee := inc.terms[0], inc.terms[1]
+			if !depender.positive {
+				depender, dependee = dependee, depender
+			}
+			return depender.describe(root) + " depends on " + dependee.describe(root)
+		}
+	}
+	if inc.isFailure(root.pkg) {
+		return "version solving failed"
+	}
+
+	var positive, negative []string
+	for _, t := range inc.terms {
+		if t.positive {
+			positive = append(positive, t.describe(root))
+		} else {
+			negative = append(negative, t.describe(root))
+		}
+	}
+	switch {
+	case len(positive) == 0 && len(negative) == 1:
+		return negative[0] + " is required"
+	case len(positive) == 0:
+		return "either " + strings.Join(negative, " or ") + " is required"
+	case len(negative) == 0 && len(positive) == 1:
+		return positive[0] + " is forbidden"
+	case len(negative) == 0 && len(positive) == 2:
+		return positive[0] + " is incompatible with " + positive[1]
+	case len(negative) == 0:
+		return "one of " + strings.Join(positive, ", ") + " must be false"
+	default:
+		return strings.Join(positive, " and ") + " requires " + strings.Join(negative, " or ")
+	}
+}
@@ -1,5 +1,7 @@
 package semver
 
+import "math"
+
 func dotTerminator(ch rune) bool {
 	return ch == '.'
 }
@@ -16,17 +18,26 @@ func plusTerminator(ch rune) bool {
 	return ch == '+'
 }
 
+func nonDigitTerminator(ch rune) bool {
+	return ch < '0' || ch > '9'
+}
+
 func isDigit(ch rune) bool {
 	return ch >= '0' && ch <= '9'
 }
 
+func isLetter(ch rune) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
 func isAlphanumericOrHyphen(ch rune) bool {
 	return (ch >= '0' && ch <= '9') || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '-'
 }
 
 // Attempts to parse a string as an integer greater than or equal to zero. A zero value must be
-// only "0"; otherwise leading zeroes are not allowed. Non-ASCII strings are not supported.
-func parsePositiveNumericString(s string) (int, bool) {
+// only "0"; otherwise leading zeroes are not allowed. Values that are too large for an int are
+// rejected rather than allowed to overflow. Non-ASCII strings are not supported.
+func parsePositiveNumericString[S string | []byte](s S) (int, bool) {
 	max := len(s)
 	if max == 0 {
 		return 0, false
@@ -40,12 +51,48 @@ func parsePositiveNumericString(s string) (int, bool) {
 		if ch == '0' && i == 0 && max > 1 {
 			return 0, false // leading zeroes aren't allowed
 		}
-		n = n*10 + (int(ch) - int('0'))
+		digit := int(ch) - int('0')
+		if n > (math.MaxInt-digit)/10 {
+			return 0, false
+		}
+		n = n*10 + digit
 	}
 	return n, true
 }
 
-func everyChar(s string, validatorFn func(rune) bool) bool {
+// Returns true if a prerelease identifier is numeric: that is, it consists only of digits and does not
+// have a leading zero. Unlike parsePositiveNumericString, this has no limit on the number of digits, since
+// the spec does not limit the size of numeric identifiers.
+func isNumericIdentifier(s string) bool {
+	if s == "" || (s[0] == '0' && len(s) > 1) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Compares two numeric prerelease identifiers by their numeric values. Since neither one has a leading
+// zero, the shorter one is the lesser, and if they are the same length they can be compared as strings.
+func compareNumericIdentifiers(s1, s2 string) int {
+	switch {
+	case len(s1) < len(s2):
+		return -1
+	case len(s1) > len(s2):
+		return 1
+	case s1 < s2:
+		return -1
+	case s1 > s2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func everyChar[S string | []byte](s S, validatorFn func(rune) bool) bool {
 	n := len(s)
 	for i := 0; i < n; i++ {
 		if !validatorFn(rune(s[i])) { // we can assume it's an ASCII string due to prior validation
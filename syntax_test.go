@@ -0,0 +1,24 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNumericIdentifier(t *testing.T) {
+	for s, expected := range map[string]bool{
+		"0":                             true,
+		"1":                             true,
+		"123":                           true,
+		"99999999999999999999999999999": true,
+		"":                              false,
+		"00":                            false,
+		"01":                            false,
+		"1a":                            false,
+		"a1":                            false,
+		"-1":                            false,
+	} {
+		assert.Equal(t, expected, isNumericIdentifier(s), "%q", s)
+	}
+}
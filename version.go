@@ -1,5 +1,7 @@
 package semver
 
+import "strconv"
+
 // Version is a semantic version as defined by the Semantic Versions 2.0.0 standard (http://semver.org).
 //
 // This type provides only parsing and simple precedence comparison, since those are the only features
@@ -36,3 +38,26 @@ func (v Version) GetPrerelease() string {
 func (v Version) GetBuild() string {
 	return v.build
 }
+
+// String returns the canonical string form of the version, such as "1.2.3-beta.1+build.5". Parsing
+// the result with ParseModeStrict always produces an identical Version.
+func (v Version) String() string {
+	return string(v.appendTo(make([]byte, 0, 16+len(v.prerelease)+len(v.build))))
+}
+
+func (v Version) appendTo(buf []byte) []byte {
+	buf = strconv.AppendInt(buf, int64(v.major), 10)
+	buf = append(buf, '.')
+	buf = strconv.AppendInt(buf, int64(v.minor), 10)
+	buf = append(buf, '.')
+	buf = strconv.AppendInt(buf, int64(v.patch), 10)
+	if v.prerelease != "" {
+		buf = append(buf, '-')
+		buf = append(buf, v.prerelease...)
+	}
+	if v.build != "" {
+		buf = append(buf, '+')
+		buf = append(buf, v.build...)
+	}
+	return buf
+}
@@ -0,0 +1,302 @@
+package semver
+
+import "math"
+
+// VersionSet is a set of versions that supports set algebra: union, intersection, complement and
+// difference, as well as subset and equality tests. It can be created from constraints with
+// NewVersionSet, or from a Range with Range.VersionSet, and is immutable once created.
+//
+// Unlike a Range, a VersionSet has a canonical form: it is stored as an ordered list of disjoint
+// intervals, each from an inclusive lower bound to an exclusive upper bound, so two VersionSets that
+// contain the same versions are always Equal regardless of how they were built. For instance, ">1.0.0"
+// and ">=1.0.1-0" are the same set, because 1.0.1-0 is the lowest version with higher precedence than
+// 1.0.0. As with Range, versions are compared purely by precedence, and build metadata is ignored.
+//
+// The zero value of VersionSet contains no versions.
+type VersionSet struct {
+	spans []span
+}
+
+// span is an interval of versions from lower (inclusive) to upper (exclusive). A missing lower bound
+// means there is no lower limit, and a missing upper bound means there is no upper limit.
+type span struct {
+	lower, upper       Version
+	hasLower, hasUpper bool
+}
+
+// lowestVersion is 0.0.0-0, the version with the lowest possible precedence.
+var lowestVersion = Version{prerelease: lowestPrerelease}
+
+// NewVersionSet returns the set of versions that satisfy every one of the constraints. With no
+// constraints, it returns the set of all versions.
+func NewVersionSet(constraints ...Constraint[Version]) VersionSet {
+	iv, ok := intersectComparators(constraints)
+	if !ok {
+		return VersionSet{}
+	}
+	s, ok := spanOf(iv)
+	if !ok {
+		return VersionSet{}
+	}
+	return VersionSet{spans: []span{s}}
+}
+
+// VersionSet returns a VersionSet that contains exactly the same versions as the Range.
+func (r Range) VersionSet() VersionSet {
+	var ret VersionSet
+	for _, set := range r.sets {
+		ret = ret.Union(NewVersionSet(set...))
+	}
+	return ret
+}
+
+// Contains returns true if the version is in the set.
+func (s VersionSet) Contains(v Version) bool {
+	for _, sp := range s.spans {
+		if sp.hasUpper && v.ComparePrecedence(sp.upper) >= 0 {
+			continue
+		}
+		return !sp.hasLower || v.ComparePrecedence(sp.lower) >= 0
+	}
+	return false
+}
+
+// IsEmpty returns true if the set contains no versions.
+func (s VersionSet) IsEmpty() bool {
+	return len(s.spans) == 0
+}
+
+// IsAll returns true if the set contains every version.
+func (s VersionSet) IsAll() bool {
+	return len(s.spans) == 1 && !s.spans[0].hasLower && !s.spans[0].hasUpper
+}
+
+// Single returns the only version in the set, and true, if the set contains exactly one precedence
+// level, such as the set created by NewVersionSet(Exactly(v)). Otherwise it returns false.
+func (s VersionSet) Single() (Version, bool) {
+	if len(s.spans) != 1 || !s.spans[0].hasUpper {
+		return Version{}, false
+	}
+	lower := lowestVersion
+	if s.spans[0].hasLower {
+		lower = s.spans[0].lower
+	}
+	if next, ok := successor(lower); !ok || next != s.spans[0].upper {
+		return Version{}, false
+	}
+	return lower, true
+}
+
+// Intersect returns the set of versions that are in both s and other.
+func (s VersionSet) Intersect(other VersionSet) VersionSet {
+	var ret VersionSet
+	i, j := 0, 0
+	for i < len(s.spans) && j < len(other.spans) {
+		a, b := s.spans[i], other.spans[j]
+		sp := a
+		if compareLowerBounds(b, a) > 0 {
+			sp.lower, sp.hasLower = b.lower, b.hasLower
+		}
+		if compareUpperBounds(b, a) < 0 {
+			sp.upper, sp.hasUpper = b.upper, b.hasUpper
+			j++
+		} else {
+			i++
+		}
+		if !sp.isEmpty() {
+			ret.spans = append(ret.spans, sp)
+		}
+	}
+	return ret
+}
+
+// Union returns the set of versions that are in either s or other.
+func (s VersionSet) Union(other VersionSet) VersionSet {
+	return s.Complement().Intersect(other.Complement()).Complement()
+}
+
+// Complement returns the set of versions that are not in s.
+func (s VersionSet) Complement() VersionSet {
+	var ret VersionSet
+	var gap span
+	for _, sp := range s.spans {
+		if sp.hasLower {
+			gap.upper, gap.hasUpper = sp.lower, true
+			if !gap.isEmpty() {
+				ret.spans = append(ret.spans, gap)
+			}
+		}
+		if !sp.hasUpper {
+			return ret
+		}
+		gap = span{lower: sp.upper, hasLower: true}
+	}
+	ret.spans = append(ret.spans, gap)
+	return ret
+}
+
+// Difference returns the set of versions that are in s but not in other.
+func (s VersionSet) Difference(other VersionSet) VersionSet {
+	return s.Intersect(other.Complement())
+}
+
+// IsSubsetOf returns true if every version in s is also in other.
+func (s VersionSet) IsSubsetOf(other VersionSet) bool {
+	return s.Intersect(other).Equal(s)
+}
+
+// IsDisjoint returns true if no version is in both s and other.
+func (s VersionSet) IsDisjoint(other VersionSet) bool {
+	return s.Intersect(other).IsEmpty()
+}
+
+// Equal returns true if s and other contain the same versions.
+func (s VersionSet) Equal(other VersionSet) bool {
+	if len(s.spans) != len(other.spans) {
+		return false
+	}
+	for i := range s.spans {
+		if s.spans[i] != other.spans[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Range returns a Range that contains exactly the same versions as the set. The Range for an empty set is
+// "<0.0.0-0".
+func (s VersionSet) Range() Range {
+	if s.IsEmpty() {
+		return Range{sets: [][]comparator{{LessThan(lowestVersion)}}}
+	}
+	r := Range{sets: make([][]comparator, 0, len(s.spans))}
+	for _, sp := range s.spans {
+		r.sets = append(r.sets, sp.comparators())
+	}
+	return r
+}
+
+// String returns the set in the normalized range syntax of Range.String, such as ">=1.2.0 <2.0.0 || =3.0.0",
+// so that it can be parsed again with ParseRange. Bounds are written with whichever of the two equivalent
+// operators gives the simpler version, so a set created from ">1.0.0" is written as ">1.0.0" rather than
+// ">=1.0.1-0". A set that contains every version is written as "*", and an empty set as "<0.0.0-0".
+func (s VersionSet) String() string {
+	return s.Range().String()
+}
+
+// spanOf converts an interval with inclusive or exclusive bounds into a span. It returns false if the
+// interval is empty.
+func spanOf(iv interval) (span, bool) {
+	var sp span
+	if iv.hasLower {
+		sp.lower, sp.hasLower = iv.lower, true
+		if !iv.lowerInclusive {
+			sp.lower, sp.hasLower = successor(iv.lower)
+			if !sp.hasLower {
+				return span{}, false // nothing is higher than the highest possible version
+			}
+		}
+		sp.lower.build = ""
+		if sp.lower == lowestVersion {
+			sp.hasLower = false
+		}
+	}
+	if iv.hasUpper {
+		sp.upper, sp.hasUpper = iv.upper, true
+		if iv.upperInclusive {
+			sp.upper, sp.hasUpper = successor(iv.upper)
+		}
+		sp.upper.build = ""
+	}
+	return sp, !sp.isEmpty()
+}
+
+func (sp span) isEmpty() bool {
+	if !sp.hasUpper {
+		return false
+	}
+	if !sp.hasLower {
+		return sp.upper == lowestVersion
+	}
+	return sp.lower.ComparePrecedence(sp.upper) >= 0
+}
+
+func (sp span) comparators() []comparator {
+	if v, ok := (VersionSet{spans: []span{sp}}).Single(); ok {
+		return []comparator{Exactly(v)}
+	}
+	var ret []comparator
+	if sp.hasLower {
+		if prev, ok := predecessor(sp.lower); ok {
+			ret = append(ret, GreaterThan(prev))
+		} else {
+			ret = append(ret, AtLeast(sp.lower))
+		}
+	}
+	if sp.hasUpper {
+		if prev, ok := predecessor(sp.upper); ok {
+			ret = append(ret, AtMost(prev))
+		} else {
+			ret = append(ret, LessThan(sp.upper))
+		}
+	}
+	return ret
+}
+
+// compareLowerBounds compares the lower bounds of two spans, treating a missing bound as the lowest.
+func compareLowerBounds(a, b span) int {
+	switch {
+	case !a.hasLower && !b.hasLower:
+		return 0
+	case !a.hasLower:
+		return -1
+	case !b.hasLower:
+		return 1
+	default:
+		return a.lower.ComparePrecedence(b.lower)
+	}
+}
+
+// compareUpperBounds compares the upper bounds of two spans, treating a missing bound as the highest.
+func compareUpperBounds(a, b span) int {
+	switch {
+	case !a.hasUpper && !b.hasUpper:
+		return 0
+	case !a.hasUpper:
+		return 1
+	case !b.hasUpper:
+		return -1
+	default:
+		return a.upper.ComparePrecedence(b.upper)
+	}
+}
+
+// successor returns the version that immediately follows v in precedence order, ignoring build metadata:
+// for instance, 1.2.4-0 for 1.2.3, or 1.2.3-beta.0 for 1.2.3-beta. It returns false if v is the highest
+// possible version.
+func successor(v Version) (Version, bool) {
+	switch {
+	case v.prerelease != "":
+		return Version{major: v.major, minor: v.minor, patch: v.patch, prerelease: v.prerelease + ".0"}, true
+	case v.patch < math.MaxInt:
+		return Version{major: v.major, minor: v.minor, patch: v.patch + 1, prerelease: lowestPrerelease}, true
+	case v.minor < math.MaxInt:
+		return Version{major: v.major, minor: v.minor + 1, prerelease: lowestPrerelease}, true
+	case v.major < math.MaxInt:
+		return Version{major: v.major + 1, prerelease: lowestPrerelease}, true
+	default:
+		return Version{}, false
+	}
+}
+
+// predecessor is the inverse of successor, for the versions where the result is simpler than v: it
+// returns 1.2.3 for 1.2.4-0, or 1.2.3-beta for 1.2.3-beta.0, and false otherwise.
+func predecessor(v Version) (Version, bool) {
+	if v.prerelease == lowestPrerelease && v.patch > 0 {
+		return Version{major: v.major, minor: v.minor, patch: v.patch - 1}, true
+	}
+	if n := len(v.prerelease); n > 2 && v.prerelease[n-2:] == ".0" {
+		return Version{major: v.major, minor: v.minor, patch: v.patch, prerelease: v.prerelease[:n-2]}, true
+	}
+	return Version{}, false
+}
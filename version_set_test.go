@@ -0,0 +1,172 @@
+package semver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseVersionSet(t *testing.T, s string) VersionSet {
+	r, err := ParseRange(s)
+	require.NoError(t, err)
+	return r.VersionSet()
+}
+
+// versionSetTestVersions returns every version from rangeTests and compareTests, plus some versions
+// that are next to the bounds of those ranges.
+func versionSetTestVersions(t *testing.T) []Version {
+	versions := []Version{{}, {prerelease: "0"}, {prerelease: "0.0"}, {patch: 1, prerelease: "0"}}
+	for _, test := range rangeTests {
+		versions = append(versions, mustParse(t, trimV(test.version)))
+	}
+	for _, test := range compareTests {
+		versions = append(versions, test.v1, test.v2)
+	}
+	return versions
+}
+
+func TestVersionSetIsEquivalentToRange(t *testing.T) {
+	versions := versionSetTestVersions(t)
+	for _, test := range rangeTests {
+		r, err := ParseRange(test.rangeString)
+		require.NoError(t, err)
+		s := r.VersionSet()
+		for _, v := range versions {
+			assert.Equal(t, r.Contains(v), s.Contains(v), "range %q, version %s", test.rangeString, v)
+		}
+
+		// the string form of the set describes the same set
+		s2 := mustParseVersionSet(t, s.String())
+		assert.True(t, s.Equal(s2), "range %q, string %q", test.rangeString, s.String())
+	}
+}
+
+func TestVersionSetAlgebra(t *testing.T) {
+	versions := versionSetTestVersions(t)
+	var sets []VersionSet
+	for _, test := range rangeTests {
+		sets = append(sets, mustParseVersionSet(t, test.rangeString))
+	}
+	for _, a := range sets {
+		complement := a.Complement()
+		assert.True(t, complement.Complement().Equal(a), "%s", a)
+		assert.True(t, a.IsDisjoint(complement), "%s", a)
+		assert.True(t, a.Union(complement).IsAll(), "%s", a)
+		for _, b := range sets {
+			union, intersection, difference := a.Union(b), a.Intersect(b), a.Difference(b)
+			for _, v := range versions {
+				inA, inB := a.Contains(v), b.Contains(v)
+				assert.Equal(t, inA || inB, union.Contains(v), "%s union %s, version %s", a, b, v)
+				assert.Equal(t, inA && inB, intersection.Contains(v), "%s intersect %s, version %s", a, b, v)
+				assert.Equal(t, inA && !inB, difference.Contains(v), "%s minus %s, version %s", a, b, v)
+			}
+			assert.True(t, union.Equal(b.Union(a)), "%s union %s", a, b)
+			assert.True(t, intersection.Equal(b.Intersect(a)), "%s intersect %s", a, b)
+			assert.True(t, intersection.IsSubsetOf(a), "%s intersect %s", a, b)
+			assert.True(t, a.IsSubsetOf(union), "%s union %s", a, b)
+			assert.Equal(t, intersection.IsEmpty(), a.IsDisjoint(b), "%s disjoint %s", a, b)
+		}
+	}
+}
+
+func TestVersionSetCanonicalForm(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+	}{
+		{">1.0.0", ">=1.0.1-0"},
+		{"<=1.0.0", "<1.0.1-0"},
+		{">1.0.0-beta", ">=1.0.0-beta.0"},
+		{"1.0.0", ">=1.0.0 <=1.0.0"},
+		{"1.0.0+build", "1.0.0"},
+		{">=0.0.0-0", "*"},
+		{"<1.0.0 || >=1.0.0", "*"},
+		{">=1.0.0 <2.0.0 || >=2.0.0 <3.0.0", ">=1.0.0 <3.0.0"},
+		{"<=1.0.0 || >1.0.0 <2.0.0", "<2.0.0"},
+		{"1.x || 1.2.x", "1.x"},
+		{">=2.0.0 <1.0.0", "<0.0.0-0"},
+	} {
+		t.Run(test.a+" = "+test.b, func(t *testing.T) {
+			a, b := mustParseVersionSet(t, test.a), mustParseVersionSet(t, test.b)
+			assert.True(t, a.Equal(b))
+			assert.Equal(t, a.String(), b.String())
+		})
+	}
+	assert.False(t, mustParseVersionSet(t, ">=1.0.0").Equal(mustParseVersionSet(t, ">1.0.0")))
+	assert.False(t, mustParseVersionSet(t, "1.x").Equal(mustParseVersionSet(t, "1.x || 3.x")))
+}
+
+func TestVersionSetString(t *testing.T) {
+	for _, test := range []struct {
+		rangeString, expected string
+	}{
+		{"*", "*"},
+		{"<0.0.0-0", "<0.0.0-0"},
+		{"1.2.3", "=1.2.3"},
+		{"0.0.0-0", "=0.0.0-0"},
+		{"1.2.3-beta", "=1.2.3-beta"},
+		{">1.2.3", ">1.2.3"},
+		{">=1.2.3", ">=1.2.3"},
+		{"<1.2.3", "<1.2.3"},
+		{"<=1.2.3", "<=1.2.3"},
+		{"<=1.2.3-beta", "<=1.2.3-beta"},
+		{"^1.2.3", ">=1.2.3 <2.0.0-0"},
+		{"1.2.x", ">=1.2.0-0 <1.3.0-0"},
+		{"1.x || >=2.5.0 || 5.0.0 - 7.2.3", ">=1.0.0-0 <2.0.0-0 || >=2.5.0"},
+		{"1.0.0 || 2.0.0", "=1.0.0 || =2.0.0"},
+		{"<1.0.0 || >1.0.0", "<1.0.0 || >1.0.0"},
+	} {
+		t.Run(test.rangeString, func(t *testing.T) {
+			s := mustParseVersionSet(t, test.rangeString)
+			assert.Equal(t, test.expected, s.String())
+			assert.Equal(t, test.expected, s.Range().String())
+		})
+	}
+	assert.Equal(t, "<1.0.0 || >1.0.0", mustParseVersionSet(t, "1.0.0").Complement().String())
+}
+
+func TestNewVersionSet(t *testing.T) {
+	v1, v2 := mustParse(t, "1.0.0"), mustParse(t, "2.0.0")
+	assert.True(t, NewVersionSet().IsAll())
+	assert.Equal(t, ">=1.0.0 <2.0.0", NewVersionSet(AtLeast(v1), LessThan(v2)).String())
+	assert.Equal(t, ">1.0.0 <=2.0.0", NewVersionSet(GreaterThan(v1), AtMost(v2)).String())
+	assert.Equal(t, "=1.0.0", NewVersionSet(Exactly(mustParse(t, "1.0.0+build"))).String())
+	assert.True(t, NewVersionSet(AtLeast(v2), LessThan(v1)).IsEmpty())
+}
+
+func TestVersionSetSingle(t *testing.T) {
+	for _, s := range []string{"1.2.3", "1.2.3-beta", "0.0.0-0", "0.0.0"} {
+		v, ok := mustParseVersionSet(t, s).Single()
+		assert.True(t, ok, s)
+		assert.Equal(t, s, v.String())
+	}
+	for _, s := range []string{"*", "<0.0.0-0", ">=1.2.3 <1.2.5", "1.2.3 || 1.2.5", ">=1.2.3"} {
+		_, ok := mustParseVersionSet(t, s).Single()
+		assert.False(t, ok, s)
+	}
+}
+
+func TestVersionSetAtHighestPossibleVersion(t *testing.T) {
+	highest := Version{major: math.MaxInt, minor: math.MaxInt, patch: math.MaxInt}
+	assert.True(t, NewVersionSet(GreaterThan(highest)).IsEmpty())
+	s := NewVersionSet(AtLeast(highest))
+	assert.True(t, s.Contains(highest))
+	assert.True(t, s.Equal(NewVersionSet(Exactly(highest))))
+	assert.True(t, NewVersionSet(AtMost(highest)).IsAll())
+
+	v := Version{major: 1, minor: 2, patch: math.MaxInt}
+	assert.Equal(t, "<1.3.0-0", NewVersionSet(AtMost(v)).String())
+	assert.True(t, NewVersionSet(GreaterThan(v)).Contains(Version{major: 1, minor: 3, prerelease: "0"}))
+
+	v = Version{major: 1, minor: math.MaxInt, patch: math.MaxInt}
+	assert.Equal(t, "<2.0.0-0", NewVersionSet(AtMost(v)).String())
+	assert.True(t, NewVersionSet(GreaterThan(v)).Contains(Version{major: 2, prerelease: "0"}))
+}
+
+func TestZeroVersionSetContainsNothing(t *testing.T) {
+	s := VersionSet{}
+	assert.True(t, s.IsEmpty())
+	assert.False(t, s.Contains(Version{}))
+	assert.True(t, s.Complement().IsAll())
+}
@@ -1 +1,36 @@
 package semver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionString(t *testing.T) {
+	for _, s := range []string{
+		"0.0.0",
+		"1.2.3",
+		"10.20.30",
+		"1.2.3-beta.1",
+		"1.2.3+build.5",
+		"1.2.3-beta.1+build.5",
+		"1.2.3----RC-SNAPSHOT.12.9.1--.12+788",
+	} {
+		t.Run(s, func(t *testing.T) {
+			v, err := Parse(s)
+			require.NoError(t, err)
+			assert.Equal(t, s, v.String())
+		})
+	}
+
+	t.Run("missing components are filled in", func(t *testing.T) {
+		v, err := ParseAs("2-rc", ParseModeAllowMissingMinorAndPatch)
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-rc", v.String())
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		assert.Equal(t, "0.0.0", Version{}.String())
+	})
+}